@@ -1,14 +1,20 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 
+	"github.com/m87wheeler/golang-vercel-cli/internal/commands"
 	"github.com/m87wheeler/golang-vercel-cli/internal/environment"
 	"github.com/m87wheeler/golang-vercel-cli/internal/helpers"
+	"github.com/m87wheeler/golang-vercel-cli/internal/history"
 	"github.com/m87wheeler/golang-vercel-cli/internal/screens"
 	"github.com/m87wheeler/golang-vercel-cli/pkg/http_client"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
 	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
 )
 
@@ -16,7 +22,8 @@ var version = "development"
 
 // main is the entry point of the application.
 func main() {
-	fmt.Printf("Version: %s\n", version)
+	// Diagnostics go to stderr, keeping stdout for output such as exported zone files
+	fmt.Fprintf(os.Stderr, "Version: %s\n", version)
 
 	// Load or configure environment
 	e := environment.NewEnvironment()
@@ -25,75 +32,72 @@ func main() {
 		log.Fatal(err)
 	}
 
-	// Define Screens
-	scr := screens.ScreensList{
-		Project:     helpers.RenderProjectScreen,
-		States:      helpers.RenderStatesScreen,
-		Deployments: helpers.RenderDeploymentsScreen,
-		Deployment:  helpers.RenderDeploymentScreen,
-		Actions:     helpers.RenderDeploymentActionsScreen,
-	}
-
-	// Project Name Menu
-	sc := scr.Project(e)
-	if sc.Err != nil {
-		log.Fatal(sc.Err)
-	}
-	projectName, ok := sc.Data["projectName"].(string)
-	if !ok {
-		log.Fatal("missing project name")
-	}
-
-	// Status Multi-choice Menu
-	sc = scr.States(e)
-	if sc.Err != nil {
-		log.Fatal(sc.Err)
-	}
-	states, ok := sc.Data["states"].([]string)
-	if !ok {
-		log.Fatal("missing states")
+	err = helpers.ConfigureMenus()
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	// Fetch Deployments
 	c := http_client.NewHttpClient()
 	v := vercel.NewVercelAPI(c, vercelEndpoint, vercelAuthKey, vercelTeamID, e.Projects)
-	dl, err := v.GetDeployments(projectName, 10, 24, states)
+	// Record every change made through the API in the history
+	stateDir, err := e.StateDir()
 	if err != nil {
-		log.Panic(err)
-	} else if len(dl.Deployments) < 1 {
-		fmt.Println("No deployments to display")
-		os.Exit(0)
+		log.Fatal(err)
 	}
+	v.Audit = history.NewLog(stateDir, e.EnvLoadFrom).Record
 
-	// Deployment Menu
-	sc = scr.Deployments(v, dl)
-	if sc.Err != nil {
-		log.Fatal(sc.Err)
+	// Flags before any subcommand answer the interactive menus ahead of time
+	fs := flag.NewFlagSet("go_vercel_cli", flag.ExitOnError)
+	project := fs.String("project", "", "project to select")
+	states := fs.String("states", "", "comma separated deployment states to select")
+	deployment := fs.String("deployment", "", "deployment ID to select")
+	action := fs.String("action", "", "deployment action to perform")
+	noInteractive := fs.Bool("no-interactive", false, "fail instead of prompting when a menu needs input")
+	yes := fs.Bool("yes", false, "confirm destructive actions without asking")
+	colorMode := fs.String("color", string(theme.COLOR_AUTO), "when to use colours: auto, always or never")
+	ascii := fs.Bool("ascii", false, "draw with ASCII symbols only")
+	fs.Parse(os.Args[1:])
+
+	mode, err := theme.ParseColorMode(*colorMode)
+	if err != nil {
+		log.Fatal(err)
 	}
-	deploymentId, ok := sc.Data["deploymentId"].(string)
-	if !ok {
-		log.Fatal("no deployment id")
+	theme.SetColorMode(mode)
+	if *ascii {
+		theme.SetGlyphs(theme.ASCII_GLYPHS)
 	}
 
-	// Deployment Data
-	sc = scr.Deployment(v, deploymentId)
-	if sc.Err != nil {
-		log.Fatal(sc.Err)
+	presets := map[string]*string{"--project": project, "--states": states, "--deployment": deployment, "--action": action}
+	for name, value := range presets {
+		if *value != "" {
+			menu.Preset(name, *value)
+		}
 	}
-	deployment, ok := sc.Data["deployment"].(vercel.DeploymentData)
-	if !ok {
-		log.Fatal("no deployment")
+	if *noInteractive {
+		menu.SetMode(menu.MODE_NONE)
 	}
+	menu.SetAssumeYes(*yes)
 
-	// Deployment Actions
-	sc = scr.Actions()
-	if sc.Err != nil {
-		log.Fatal(sc.Err)
-	}
-	action, ok := sc.Data["action"].(string)
-	if !ok {
-		log.Fatal("no deployment id")
+	// Run a subcommand when one is given, otherwise start the interactive menus
+	if fs.NArg() > 0 {
+		err := commands.Run(&commands.Context{Env: e, API: v}, fs.Args())
+		if errors.Is(err, menu.ErrInterrupted) {
+			os.Exit(130)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	helpers.DeploymentAction(v, action, deploymentId, deployment)
+	s := screens.NewScreens(helpers.DefaultScreens(), &screens.Session{Env: e, API: v, NoInteractive: *noInteractive}, screens.PROJECT)
+	err = s.Run()
+	if errors.Is(err, menu.ErrInterrupted) {
+		// Exit as a shell would after an interrupt
+		os.Exit(130)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
 }
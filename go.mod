@@ -3,12 +3,10 @@ module github.com/m87wheeler/golang-vercel-cli
 go 1.23.3
 
 require (
-	github.com/buger/goterm v1.0.4 // indirect
-	github.com/fatih/color v1.18.0 // indirect
-	github.com/joho/godotenv v1.5.1 // indirect
-	github.com/mattn/go-colorable v0.1.13 // indirect
-	github.com/mattn/go-isatty v0.0.20 // indirect
-	github.com/pkg/term v1.1.0 // indirect
-	golang.org/x/sys v0.27.0 // indirect
-	golang.org/x/term v0.26.0 // indirect
+	github.com/buger/goterm v1.0.4
+	github.com/joho/godotenv v1.5.1
+	github.com/pkg/term v1.1.0
+	golang.org/x/term v0.26.0
 )
+
+require golang.org/x/sys v0.27.0 // indirect
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/utils"
+)
+
+var aliasCommand = &Command{
+	Name:        "alias",
+	Usage:       "alias <ls|set|rm>",
+	Description: "Manage custom aliases for deployments",
+	Subcommands: []*Command{
+		{
+			Name:        "ls",
+			Usage:       "alias ls [--project name]",
+			Description: "List aliases, optionally for a single project",
+			Run:         runAliasList,
+		},
+		{
+			Name:        "set",
+			Usage:       "alias set <deployment-id> <alias>",
+			Description: "Point an alias at a deployment",
+			Run:         runAliasSet,
+		},
+		{
+			Name:        "rm",
+			Usage:       "alias rm <alias> [--yes]",
+			Description: "Remove an alias",
+			Run:         runAliasRemove,
+		},
+	},
+}
+
+func runAliasList(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("alias ls", flag.ContinueOnError)
+	project := fs.String("project", "", "project name")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	aliases, err := ctx.API.ListAliases(*project)
+	if err != nil {
+		return err
+	}
+	if len(aliases) == 0 {
+		fmt.Fprintln(ctx.Out, "No aliases found")
+		return nil
+	}
+
+	w := newTable(ctx.Out)
+	fmt.Fprintln(w, "Alias\tDeployment\tCreated")
+	for _, a := range aliases {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", a.Alias, a.DeploymentID, utils.ElapsedTime(a.CreatedAt/int64(time.Second/time.Millisecond)))
+	}
+	return w.Flush()
+}
+
+func runAliasSet(ctx *Context, args []string) error {
+	if len(args) != 2 {
+		return ErrUsage
+	}
+
+	a, err := ctx.API.AssignAlias(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out, "%s now points to %s\n", a.Alias, a.DeploymentID)
+	return nil
+}
+
+func runAliasRemove(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("alias rm", flag.ContinueOnError)
+	yes := fs.Bool("yes", false, "skip the confirmation")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	if err := confirm(*yes, fmt.Sprintf("Remove alias %s?", args[0]), ""); err != nil {
+		return err
+	}
+
+	if err := ctx.API.RemoveAlias(args[0]); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out, "Removed alias %s\n", args[0])
+	return nil
+}
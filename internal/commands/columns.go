@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/helpers"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+)
+
+var columnsCommand = &Command{
+	Name:        "columns",
+	Usage:       "columns [column...] [--reset]",
+	Description: "Choose the columns of the deployments list, from a menu when none are given",
+	Run:         runColumns,
+}
+
+func runColumns(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("columns", flag.ContinueOnError)
+	reset := fs.Bool("reset", false, "restore the default columns")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	names := args
+	switch {
+	case *reset:
+		names = nil
+	case len(names) == 0:
+		m := menu.NewMenu("Deployment columns")
+		m.MinSelected = 1
+		for _, n := range helpers.DeploymentColumnNames() {
+			m.AddItem(n, n)
+		}
+		for _, c := range helpers.SelectedDeploymentColumns() {
+			m.Select(c.Name)
+		}
+		selected, ok := m.DisplayMultiChoice()
+		if !ok {
+			return helpers.Interrupted(m)
+		}
+		if names, err = orderColumns(selected); err != nil || names == nil {
+			return err
+		}
+	}
+
+	if err := helpers.SaveDeploymentColumns(ctx.Env, names); err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		names = helpers.DEFAULT_DEPLOYMENT_COLUMNS
+	}
+	fmt.Fprintf(ctx.Out, "Deployments list columns: %s\n", strings.Join(names, ", "))
+	return nil
+}
+
+// orderColumns asks for the order of the chosen columns, one position at a
+// time, offering them in their current order so Enter keeps it. It returns
+// nil if the user went back.
+func orderColumns(names []string) ([]string, error) {
+	current := []string{}
+	for _, c := range helpers.SelectedDeploymentColumns() {
+		current = append(current, c.Name)
+	}
+	position := func(n string) int {
+		if i := slices.Index(current, n); i >= 0 {
+			return i
+		}
+		return len(current)
+	}
+	remaining := slices.Clone(names)
+	slices.SortStableFunc(remaining, func(a, b string) int { return position(a) - position(b) })
+
+	ordered := make([]string, 0, len(names))
+	for len(remaining) > 1 {
+		m := menu.NewMenu(fmt.Sprintf("Column %d of %d", len(ordered)+1, len(names)))
+		for _, n := range remaining {
+			m.AddItem(n, n)
+		}
+		chosen := m.Display()
+		if chosen == "" {
+			return nil, helpers.Interrupted(m)
+		}
+		ordered = append(ordered, chosen)
+		remaining = slices.DeleteFunc(remaining, func(n string) bool { return n == chosen })
+	}
+	return append(ordered, remaining...), nil
+}
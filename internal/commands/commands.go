@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/environment"
+	"github.com/m87wheeler/golang-vercel-cli/internal/helpers"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+// Context carries the configured environment and API client into every command.
+type Context struct {
+	Env *environment.Environment
+	API *vercel.VercelAPI
+	Out io.Writer
+}
+
+type Command struct {
+	Name        string
+	Usage       string
+	Description string
+	Run         func(ctx *Context, args []string) error
+	Subcommands []*Command
+}
+
+// ErrUsage is returned by a command when it was invoked with invalid arguments.
+var ErrUsage = errors.New("invalid usage")
+
+// List returns every top level command, in the order they are shown in help.
+func List() []*Command {
+	return []*Command{
+		aliasCommand,
+		domainsCommand,
+		dnsCommand,
+		inspectCommand,
+		openCommand,
+		diffCommand,
+		downloadCommand,
+		deployCommand,
+		dashboardCommand,
+		columnsCommand,
+		historyCommand,
+	}
+}
+
+// IsCommand reports whether name is a known top level command.
+func IsCommand(name string) bool {
+	return lookup(List(), name) != nil || name == "help"
+}
+
+// Run dispatches args to the matching command or subcommand.
+func Run(ctx *Context, args []string) error {
+	if ctx.Out == nil {
+		ctx.Out = os.Stdout
+	}
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		PrintUsage(ctx.Out, List())
+		return nil
+	}
+	return dispatch(ctx, List(), args, "")
+}
+
+func dispatch(ctx *Context, cmds []*Command, args []string, parent string) error {
+	c := lookup(cmds, args[0])
+	if c == nil {
+		PrintUsage(ctx.Out, cmds)
+		return fmt.Errorf("unknown command %q", strings.TrimSpace(parent+" "+args[0]))
+	}
+
+	path := strings.TrimSpace(parent + " " + c.Name)
+	rest := args[1:]
+	// Commands that also run on their own do so unless a subcommand is named
+	if len(c.Subcommands) > 0 && (c.Run == nil || len(rest) > 0 && lookup(c.Subcommands, rest[0]) != nil) {
+		if len(rest) == 0 || rest[0] == "-h" || rest[0] == "--help" {
+			PrintUsage(ctx.Out, c.Subcommands)
+			return nil
+		}
+		return dispatch(ctx, c.Subcommands, rest, path)
+	}
+
+	err := c.Run(ctx, rest)
+	if errors.Is(err, ErrUsage) {
+		fmt.Fprintf(ctx.Out, "Usage: %s\n", c.Usage)
+	}
+	return err
+}
+
+func lookup(cmds []*Command, name string) *Command {
+	for _, c := range cmds {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// PrintUsage writes a usage line and description for each command.
+func PrintUsage(out io.Writer, cmds []*Command) {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range cmds {
+		fmt.Fprintf(w, "  %s\t%s\n", c.Usage, c.Description)
+	}
+	w.Flush()
+}
+
+// parseFlags parses args with fs, allowing flags to appear after positional
+// arguments, and returns the positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrUsage, err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// confirm asks the user to confirm a destructive command, unless yes is set
+// by its --yes flag. When expect is set the user must type it to confirm.
+func confirm(yes bool, prompt, expect string) error {
+	if yes {
+		return nil
+	}
+	ok, err := menu.Confirm(prompt, expect)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return helpers.ErrNotConfirmed
+	}
+	return nil
+}
+
+// newTable returns a tabwriter for aligned command output.
+func newTable(out io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+}
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/helpers"
+	"github.com/m87wheeler/golang-vercel-cli/internal/screens"
+)
+
+var dashboardCommand = &Command{
+	Name:        "dashboard",
+	Usage:       "dashboard [--project name]",
+	Description: "Browse projects, deployments and build logs in a full-screen view",
+	Run:         runDashboard,
+}
+
+func runDashboard(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("dashboard", flag.ContinueOnError)
+	project := fs.String("project", "", "project to show first")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		return ErrUsage
+	}
+	if _, ok := ctx.Env.Projects[*project]; *project != "" && !ok {
+		return fmt.Errorf("unknown project %q", *project)
+	}
+
+	s := screens.NewScreens(helpers.DefaultScreens(), &screens.Session{Env: ctx.Env, API: ctx.API, ProjectName: *project}, screens.DASHBOARD)
+	return s.Run()
+}
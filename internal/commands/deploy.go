@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/deploy"
+	"github.com/m87wheeler/golang-vercel-cli/internal/helpers"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+var deployCommand = &Command{
+	Name:        "deploy",
+	Usage:       "deploy [dir] --project name [--prod] [--prebuilt] [--no-wait]",
+	Description: "Upload a local directory, or its prebuilt .vercel/output, and deploy it",
+	Run:         runDeploy,
+}
+
+func runDeploy(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("deploy", flag.ContinueOnError)
+	project := fs.String("project", "", "project name")
+	prod := fs.Bool("prod", false, "deploy to production")
+	noWait := fs.Bool("no-wait", false, "don't follow the build")
+	prebuilt := fs.Bool("prebuilt", false, "deploy the Build Output API directory without building")
+	concurrency := fs.Int("concurrency", deploy.DEFAULT_CONCURRENCY, "number of concurrent uploads")
+	framework := fs.String("framework", "", "override the project's framework preset")
+	buildCommand := fs.String("build-command", "", "override the project's build command")
+	installCommand := fs.String("install-command", "", "override the project's install command")
+	outputDirectory := fs.String("output-directory", "", "override the project's output directory")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 1 || *project == "" {
+		return ErrUsage
+	}
+
+	projectId, ok := ctx.API.ProjectIDs[*project]
+	if !ok {
+		return errors.New("No project ID found for " + *project)
+	}
+
+	dir := "."
+	if len(args) == 1 {
+		dir = args[0]
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	target := vercel.PREVIEW
+	if *prod {
+		target = vercel.PRODUCTION
+	}
+
+	settings := &vercel.ProjectSettings{}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "framework":
+			settings.Framework = framework
+		case "build-command":
+			settings.BuildCommand = buildCommand
+		case "install-command":
+			settings.InstallCommand = installCommand
+		case "output-directory":
+			settings.OutputDirectory = outputDirectory
+		}
+	})
+	if *settings == (vercel.ProjectSettings{}) {
+		settings = nil
+	}
+
+	d, err := deploy.Deploy(ctx.API, deploy.Options{
+		Dir:             dir,
+		Name:            *project,
+		Project:         projectId,
+		Target:          target,
+		ProjectSettings: settings,
+		Concurrency:     *concurrency,
+		Prebuilt:        *prebuilt,
+		Out:             ctx.Out,
+	})
+	if err != nil {
+		return err
+	}
+
+	return followDeployment(ctx, d, *noWait)
+}
+
+// followDeployment reports a newly created deployment and, unless noWait is
+// set, watches it until the build finishes.
+func followDeployment(ctx *Context, d vercel.DeploymentData, noWait bool) error {
+	fmt.Fprintf(ctx.Out, "Created %s\n", d.ID)
+	fmt.Fprintf(ctx.Out, "Inspect: %s\n", d.InspectorURL)
+	if noWait {
+		return nil
+	}
+
+	d, err := helpers.WatchDeployment(ctx.API, d.ID, helpers.DEFAULT_WATCH_INTERVAL, ctx.Out)
+	if err != nil {
+		return err
+	}
+	if d.ReadyState != string(vercel.READY) {
+		return fmt.Errorf("deployment %s finished as %s", d.ID, d.ReadyState)
+	}
+
+	fmt.Fprintf(ctx.Out, "Ready: https://%s\n", d.URL)
+	return nil
+}
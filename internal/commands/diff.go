@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/helpers"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+)
+
+var diffCommand = &Command{
+	Name:        "diff",
+	Usage:       "diff <deployment-a> <deployment-b> [--all]",
+	Description: "Show what changed from one deployment to another, from its commits and settings to its files",
+	Run:         runDiff,
+}
+
+func runDiff(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	all := fs.Bool("all", false, fmt.Sprintf("list every changed file, not just the first %d", helpers.DIFF_FILE_LINES))
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 2 {
+		return ErrUsage
+	}
+
+	diff, err := helpers.CompareDeployments(ctx.API, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	fileLines := helpers.DIFF_FILE_LINES
+	if *all {
+		fileLines = 0
+	}
+	for _, line := range menu.FormatInfoSections(diff.Sections(fileLines)) {
+		fmt.Fprintln(ctx.Out, line)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+var dnsCommand = &Command{
+	Name:        "dns",
+	Usage:       "dns <ls|add|rm|import|export>",
+	Description: "Manage DNS records of domains using Vercel nameservers",
+	Subcommands: []*Command{
+		{
+			Name:        "ls",
+			Usage:       "dns ls <domain>",
+			Description: "List a domain's DNS records",
+			Run:         runDNSList,
+		},
+		{
+			Name:  "add",
+			Usage: "dns add <domain> <name> <type> <value...> [--ttl seconds]",
+			Description: "Add a record, e.g. `A 76.76.21.21`, `MX <value> <priority>` " +
+				"or `SRV <priority> <weight> <port> <target>`",
+			Run: runDNSAdd,
+		},
+		{
+			Name:        "rm",
+			Usage:       "dns rm <domain> <record-id> [--yes]",
+			Description: "Remove a DNS record",
+			Run:         runDNSRemove,
+		},
+		{
+			Name:        "import",
+			Usage:       "dns import <domain> <zonefile>",
+			Description: "Add the records of a BIND zone file that don't already exist",
+			Run:         runDNSImport,
+		},
+		{
+			Name:        "export",
+			Usage:       "dns export <domain> [zonefile]",
+			Description: "Write a domain's records as a BIND zone file, or to stdout, commenting out types import can't read",
+			Run:         runDNSExport,
+		},
+	},
+}
+
+func runDNSList(ctx *Context, args []string) error {
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	records, err := ctx.API.ListDNSRecords(args[0])
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		fmt.Fprintln(ctx.Out, "No DNS records found")
+		return nil
+	}
+
+	w := newTable(ctx.Out)
+	fmt.Fprintln(w, "ID\tName\tType\tValue\tTTL")
+	for _, r := range records {
+		name := r.Name
+		if name == "" {
+			name = "@"
+		}
+		value := r.Value
+		switch r.Type {
+		case vercel.DNS_MX:
+			value = fmt.Sprintf("%d %s", r.MXPriority, r.Value)
+		case vercel.DNS_SRV:
+			value = fmt.Sprintf("%d %s", r.Priority, r.Value)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", r.ID, name, r.Type, value, r.TTL)
+	}
+	return w.Flush()
+}
+
+func runDNSAdd(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("dns add", flag.ContinueOnError)
+	ttl := fs.Int("ttl", vercel.DefaultDNSRecordTTL, "time to live in seconds")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 4 {
+		return ErrUsage
+	}
+
+	domain := args[0]
+	record := vercel.DNSRecord{Name: args[1], TTL: *ttl}
+	if record.Name == "@" {
+		record.Name = ""
+	}
+	record.Type, err = vercel.ToDNSRecordType(args[2])
+	if err != nil {
+		return err
+	}
+
+	values := args[3:]
+	switch record.Type {
+	case vercel.DNS_MX:
+		if len(values) != 2 {
+			return ErrUsage
+		}
+		record.Value = values[0]
+		record.MXPriority, err = strconv.Atoi(values[1])
+		if err != nil {
+			return fmt.Errorf("invalid MX priority %q", values[1])
+		}
+	case vercel.DNS_SRV:
+		if len(values) != 4 {
+			return ErrUsage
+		}
+		record.Priority, err = strconv.Atoi(values[0])
+		if err != nil {
+			return fmt.Errorf("invalid SRV priority %q", values[0])
+		}
+		record.Value = strings.Join(values[1:], " ")
+	default:
+		record.Value = strings.Join(values, " ")
+	}
+
+	id, err := ctx.API.CreateDNSRecord(domain, record)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out, "Created %s record %s\n", record.Type, id)
+	return nil
+}
+
+func runDNSRemove(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("dns rm", flag.ContinueOnError)
+	yes := fs.Bool("yes", false, "skip the confirmation")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 2 {
+		return ErrUsage
+	}
+
+	if err := confirm(*yes, fmt.Sprintf("Remove record %s from %s?", args[1], args[0]), ""); err != nil {
+		return err
+	}
+
+	if err := ctx.API.RemoveDNSRecord(args[0], args[1]); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out, "Removed record %s\n", args[1])
+	return nil
+}
+
+func runDNSImport(ctx *Context, args []string) error {
+	if len(args) != 2 {
+		return ErrUsage
+	}
+	domain := args[0]
+
+	f, err := os.Open(args[1])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	records, err := vercel.ParseZoneFile(f, domain)
+	if err != nil {
+		return err
+	}
+
+	existing, err := ctx.API.ListDNSRecords(domain)
+	if err != nil {
+		return err
+	}
+
+	created := 0
+	for _, r := range records {
+		if containsDNSRecord(existing, r) {
+			continue
+		}
+		if _, err := ctx.API.CreateDNSRecord(domain, r); err != nil {
+			return fmt.Errorf("creating %s record %q: %w", r.Type, r.Name, err)
+		}
+		created++
+	}
+
+	fmt.Fprintf(ctx.Out, "Imported %d of %d records, %d already existed\n", created, len(records), len(records)-created)
+	return nil
+}
+
+func runDNSExport(ctx *Context, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return ErrUsage
+	}
+
+	records, err := ctx.API.ListDNSRecords(args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		return vercel.WriteZoneFile(ctx.Out, args[0], records)
+	}
+
+	f, err := os.Create(args[1])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := vercel.WriteZoneFile(f, args[0], records); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out, "Exported %d records to %s\n", len(records), args[1])
+	return nil
+}
+
+func containsDNSRecord(records []vercel.DNSRecord, record vercel.DNSRecord) bool {
+	for _, r := range records {
+		if vercel.SameDNSRecord(r, record) {
+			return true
+		}
+	}
+	return false
+}
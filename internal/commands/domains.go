@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+var domainsCommand = &Command{
+	Name:        "domains",
+	Usage:       "domains <ls|add|rm|inspect>",
+	Description: "Manage domains and check their configuration",
+	Subcommands: []*Command{
+		{
+			Name:        "ls",
+			Usage:       "domains ls",
+			Description: "List domains and their verification status",
+			Run:         runDomainsList,
+		},
+		{
+			Name:        "add",
+			Usage:       "domains add <domain>",
+			Description: "Add an existing domain",
+			Run:         runDomainsAdd,
+		},
+		{
+			Name:        "rm",
+			Usage:       "domains rm <domain> [--yes]",
+			Description: "Remove a domain",
+			Run:         runDomainsRemove,
+		},
+		{
+			Name:        "inspect",
+			Usage:       "domains inspect <domain>",
+			Description: "Show a domain's verification status and DNS configuration",
+			Run:         runDomainsInspect,
+		},
+	},
+}
+
+func runDomainsList(ctx *Context, args []string) error {
+	if len(args) != 0 {
+		return ErrUsage
+	}
+
+	domains, err := ctx.API.ListDomains()
+	if err != nil {
+		return err
+	}
+	if len(domains) == 0 {
+		fmt.Fprintln(ctx.Out, "No domains found")
+		return nil
+	}
+
+	w := newTable(ctx.Out)
+	fmt.Fprintln(w, "Domain\tVerified\tNameservers")
+	for _, d := range domains {
+		ns := "third party"
+		if vercel.IsVercelNameservers(d) {
+			ns = "vercel"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", d.Name, yesNo(d.Verified), ns)
+	}
+	return w.Flush()
+}
+
+func runDomainsAdd(ctx *Context, args []string) error {
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	d, err := ctx.API.AddDomain(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out, "Added %s\n", d.Name)
+	if !d.Verified {
+		fmt.Fprintf(ctx.Out, "Run `domains inspect %s` to see how to configure it\n", d.Name)
+	}
+	return nil
+}
+
+func runDomainsRemove(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("domains rm", flag.ContinueOnError)
+	yes := fs.Bool("yes", false, "skip the confirmation")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	if err := confirm(*yes, fmt.Sprintf("Remove %s and its DNS records?", args[0]), args[0]); err != nil {
+		return err
+	}
+
+	if err := ctx.API.RemoveDomain(args[0]); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out, "Removed %s\n", args[0])
+	return nil
+}
+
+func runDomainsInspect(ctx *Context, args []string) error {
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	d, err := ctx.API.GetDomain(args[0])
+	if err != nil {
+		return err
+	}
+	config, err := ctx.API.GetDomainConfig(args[0])
+	if err != nil {
+		return err
+	}
+
+	w := newTable(ctx.Out)
+	fmt.Fprintf(w, "Domain\t%s\n", d.Name)
+	fmt.Fprintf(w, "Verified\t%s\n", yesNo(d.Verified))
+	fmt.Fprintf(w, "Nameservers\t%s\n", strings.Join(d.Nameservers, ", "))
+	fmt.Fprintf(w, "Intended nameservers\t%s\n", strings.Join(d.IntendedNameservers, ", "))
+	fmt.Fprintf(w, "Configured by\t%s\n", config.ConfiguredBy)
+	fmt.Fprintf(w, "Misconfigured\t%s\n", yesNo(config.Misconfigured))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if config.Misconfigured {
+		fmt.Fprintln(ctx.Out, "\nTo configure this domain, either set the nameservers to:")
+		for _, ns := range d.IntendedNameservers {
+			fmt.Fprintf(ctx.Out, "  %s\n", ns)
+		}
+		fmt.Fprintln(ctx.Out, "or add the following record with your DNS provider:")
+		for _, hint := range vercel.DNSConfigHints(d) {
+			fmt.Fprintf(ctx.Out, "  %s\n", hint)
+		}
+	}
+	return nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/history"
+)
+
+var historyCommand = &Command{
+	Name:        "history",
+	Usage:       "history [--action a] [--project name] [--deployment id] [--user name] [--since 24h] [--limit n]",
+	Description: "Show the changes made through the CLI, newest last",
+	Run:         runHistory,
+	Subcommands: []*Command{
+		{
+			Name:        "undo",
+			Usage:       "history undo [n] [--yes]",
+			Description: "Reverse change n of the history, or the last one that can be reversed",
+			Run:         runHistoryUndo,
+		},
+	},
+}
+
+// historyLog returns the audit log of the configured environment.
+func historyLog(ctx *Context) (*history.Log, error) {
+	dir, err := ctx.Env.StateDir()
+	if err != nil {
+		return nil, err
+	}
+	return history.NewLog(dir, ctx.Env.EnvLoadFrom), nil
+}
+
+func runHistory(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	var filter history.Filter
+	fs.StringVar(&filter.Action, "action", "", "only show this action, e.g. cancel, redeploy or alias-set; there is no promote action, pointing a production domain with alias-set is the nearest")
+	fs.StringVar(&filter.Project, "project", "", "only show changes to this project")
+	fs.StringVar(&filter.Deployment, "deployment", "", "only show changes to this deployment")
+	fs.StringVar(&filter.User, "user", "", "only show changes made by this local user")
+	fs.DurationVar(&filter.Since, "since", 0, "only show changes made this long ago or since, e.g. 24h")
+	limit := fs.Int("limit", 20, "show at most this many of the newest changes, 0 for all")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		return ErrUsage
+	}
+
+	l, err := historyLog(ctx)
+	if err != nil {
+		return err
+	}
+	entries, err := l.Read()
+	if err != nil {
+		return err
+	}
+	matched := filter.Apply(entries)
+	if len(matched) == 0 {
+		fmt.Fprintln(ctx.Out, "No changes recorded")
+		return nil
+	}
+	if *limit > 0 && len(matched) > *limit {
+		matched = matched[len(matched)-*limit:]
+	}
+
+	w := newTable(ctx.Out)
+	fmt.Fprintln(w, "#\tTime\tUser\tAction\tProject\tDeployments\tTarget\tResult")
+	for _, e := range matched {
+		action := e.Action
+		if e.Undoes > 0 {
+			action += fmt.Sprintf(" (undo #%d)", e.Undoes)
+		}
+		target := e.Alias
+		if e.Domain != "" {
+			target = strings.TrimSpace(e.Domain + " " + e.Record)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.Line, e.Time.Local().Format("2006-01-02 15:04:05"),
+			e.User, action, e.Project, strings.Join(e.Deployments, ", "), target, e.Result)
+	}
+	return w.Flush()
+}
+
+func runHistoryUndo(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("history undo", flag.ContinueOnError)
+	yes := fs.Bool("yes", false, "skip the confirmation")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		return ErrUsage
+	}
+
+	l, err := historyLog(ctx)
+	if err != nil {
+		return err
+	}
+	entries, err := l.Read()
+	if err != nil {
+		return err
+	}
+
+	var entry history.Entry
+	var undo history.Undo
+	if len(args) == 1 {
+		line, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("%w: %q isn't a change number", ErrUsage, args[0])
+		}
+		i := slices.IndexFunc(entries, func(e history.Entry) bool { return e.Line == line })
+		if i < 0 {
+			return fmt.Errorf("No change #%d in the history", line)
+		}
+		entry = entries[i]
+		var ok bool
+		if undo, ok = history.Inverse(entry); !ok {
+			return fmt.Errorf("Change #%d (%s) can't be undone", line, entry.Action)
+		}
+		if history.Undone(entries, entry) {
+			fmt.Fprintf(ctx.Out, "Change #%d was already undone\n", line)
+		}
+	} else {
+		// The newest change that can still be undone, leaving out undos so
+		// undoing again goes further back
+		found := false
+		for i := len(entries) - 1; i >= 0 && !found; i-- {
+			u, ok := history.Inverse(entries[i])
+			if ok && entries[i].Undoes == 0 && !history.Undone(entries, entries[i]) {
+				entry, undo, found = entries[i], u, true
+			}
+		}
+		if !found {
+			return errors.New("Nothing in the history can be undone")
+		}
+	}
+
+	prompt := fmt.Sprintf("Undo change #%d (%s)? %s.", entry.Line, entry.Action, undo.Description)
+	if err := confirm(*yes, prompt, ""); err != nil {
+		return err
+	}
+
+	// Record the calls made as undoing the entry
+	l.Undoes = entry.Line
+	audit := ctx.API.Audit
+	ctx.API.Audit = l.Record
+	defer func() { ctx.API.Audit = audit }()
+	if err := undo.Run(ctx.API); err != nil {
+		return err
+	}
+	fmt.Fprintln(ctx.Out, "Done: "+undo.Description)
+	return nil
+}
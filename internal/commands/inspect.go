@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/helpers"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+)
+
+var inspectCommand = &Command{
+	Name:        "inspect",
+	Usage:       "inspect <deployment-id> [--files]",
+	Description: "Show a deployment's details, or its file tree with --files",
+	Run:         runInspect,
+}
+
+var downloadCommand = &Command{
+	Name:        "download",
+	Usage:       "download <deployment-id> [path] [--out dir]",
+	Description: "Download a deployment file, or a directory of them, defaulting to the whole tree",
+	Run:         runDownload,
+}
+
+func runInspect(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	files := fs.Bool("files", false, "show the deployment's file tree")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	if *files {
+		tree, err := ctx.API.GetDeploymentFiles(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(ctx.Out, "/")
+		for _, l := range helpers.FormatFileTree(tree) {
+			fmt.Fprintln(ctx.Out, l)
+		}
+		return nil
+	}
+
+	d, err := ctx.API.GetDeployment(args[0])
+	if err != nil {
+		return err
+	}
+	for _, line := range menu.FormatInfoSections(helpers.FormatDeploymentDetails(d, helpers.DeploymentErrorLog(ctx.API, d))) {
+		fmt.Fprintln(ctx.Out, line)
+	}
+	return nil
+}
+
+func runDownload(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("download", flag.ContinueOnError)
+	out := fs.String("out", ".", "directory to write files to")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 1 || len(args) > 2 {
+		return ErrUsage
+	}
+
+	p := "/"
+	if len(args) == 2 {
+		p = args[1]
+	}
+
+	n, err := helpers.DownloadDeploymentFiles(ctx.API, args[0], p, *out)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out, "Downloaded %d files to %s\n", n, *out)
+	return nil
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/helpers"
+)
+
+var openCommand = &Command{
+	Name:        "open",
+	Usage:       "open <deployment-id> [--inspector|--alias N] [--copy]",
+	Description: "Open a deployment, its inspector or one of its aliases in the browser, or copy the URL with --copy",
+	Run:         runOpen,
+}
+
+func runOpen(ctx *Context, args []string) error {
+	fs := flag.NewFlagSet("open", flag.ContinueOnError)
+	inspector := fs.Bool("inspector", false, "open the deployment's inspector")
+	alias := fs.Int("alias", 0, "open the deployment's alias N, counted from 1 as listed by inspect")
+	copy := fs.Bool("copy", false, "copy the URL to the clipboard instead of opening it")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return ErrUsage
+	}
+	if *inspector && *alias != 0 {
+		return errors.New("pass either --inspector or --alias, not both")
+	}
+	if *alias < 0 {
+		return errors.New("--alias counts from 1")
+	}
+
+	d, err := ctx.API.GetDeployment(args[0])
+	if err != nil {
+		return err
+	}
+	url, err := helpers.DeploymentURL(d, *inspector, *alias)
+	if err != nil {
+		return err
+	}
+	if *copy {
+		return helpers.CopyURL(url, ctx.Out)
+	}
+	return helpers.OpenURL(url, ctx.Out)
+}
@@ -0,0 +1,94 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+type Options struct {
+	Dir             string
+	Name            string
+	Project         string
+	Target          vercel.DeploymentTarget
+	ProjectSettings *vercel.ProjectSettings
+	Concurrency     int
+	Prebuilt        bool
+	Out             io.Writer
+}
+
+// Deploy uploads the contents of opts.Dir and creates a deployment from them.
+// Only files Vercel doesn't already have are uploaded.
+func Deploy(v *vercel.VercelAPI, opts Options) (vercel.DeploymentData, error) {
+	if opts.Prebuilt {
+		return DeployPrebuilt(v, opts)
+	}
+
+	ignore, err := LoadIgnore(opts.Dir)
+	if err != nil {
+		return vercel.DeploymentData{}, err
+	}
+
+	files, err := CollectFiles(opts.Dir, ignore)
+	if err != nil {
+		return vercel.DeploymentData{}, err
+	}
+	if len(files) == 0 {
+		return vercel.DeploymentData{}, errors.New("No files to deploy in " + opts.Dir)
+	}
+
+	return CreateFromFiles(v, files, opts)
+}
+
+// CreateFromFiles creates a deployment from files, uploading any that Vercel
+// reports as missing and then retrying.
+func CreateFromFiles(v *vercel.VercelAPI, files []LocalFile, opts Options) (vercel.DeploymentData, error) {
+	fmt.Fprintf(opts.Out, "Deploying %d files from %s\n", len(files), opts.Dir)
+
+	body := vercel.CreateDeploymentBody{
+		Name:            opts.Name,
+		Project:         opts.Project,
+		Target:          opts.Target,
+		Files:           DeploymentFiles(files),
+		ProjectSettings: opts.ProjectSettings,
+	}
+
+	d, err := v.CreateDeployment(body)
+	missing, ok := vercel.MissingFiles(err)
+	if !ok {
+		return d, err
+	}
+
+	if err := Upload(v, files, missing, opts.Concurrency, opts.Out); err != nil {
+		return d, err
+	}
+
+	return v.CreateDeployment(body)
+}
+
+// DeployPrebuilt validates and uploads the Build Output API directory of
+// opts.Dir, creating a deployment that skips the build step.
+func DeployPrebuilt(v *vercel.VercelAPI, opts Options) (vercel.DeploymentData, error) {
+	outputDir := filepath.Join(opts.Dir, filepath.FromSlash(BUILD_OUTPUT_DIR))
+	if err := ValidateBuildOutput(outputDir); err != nil {
+		return vercel.DeploymentData{}, err
+	}
+
+	// Build output is uploaded whole: neither .vercelignore nor the default
+	// ignores apply, as functions bundle their own node_modules
+	files, err := CollectFiles(outputDir, &Ignore{})
+	if err != nil {
+		return vercel.DeploymentData{}, err
+	}
+	for i := range files {
+		files[i].Path = path.Join(BUILD_OUTPUT_DIR, files[i].Path)
+	}
+
+	// Project settings only affect the build, which prebuilt output skips
+	opts.ProjectSettings = nil
+	return CreateFromFiles(v, files, opts)
+}
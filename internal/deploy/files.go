@@ -0,0 +1,109 @@
+package deploy
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+// LocalFile is a file on disk that will be part of a deployment.
+type LocalFile struct {
+	Path    string // slash separated path relative to the deployed directory
+	AbsPath string
+	SHA     string
+	Size    int64
+	Mode    fs.FileMode
+	Symlink string // the link target, uploaded as the contents of a symlink
+}
+
+// CollectFiles walks dir and hashes every file not matched by ignore.
+func CollectFiles(dir string, ignore *Ignore) ([]LocalFile, error) {
+	var files []LocalFile
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if ignore.Match(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		f := LocalFile{Path: rel, AbsPath: p, Mode: info.Mode()}
+
+		if info.Mode()&fs.ModeSymlink != 0 {
+			f.Symlink, err = os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			sum := sha1.Sum([]byte(f.Symlink))
+			f.SHA, f.Size = hex.EncodeToString(sum[:]), int64(len(f.Symlink))
+		} else {
+			f.SHA, f.Size, err = hashFile(p)
+			if err != nil {
+				return err
+			}
+		}
+
+		files = append(files, f)
+		return nil
+	})
+
+	return files, err
+}
+
+// DeploymentFiles returns the file list sent when creating a deployment.
+func DeploymentFiles(files []LocalFile) []vercel.DeploymentFile {
+	df := make([]vercel.DeploymentFile, 0, len(files))
+	for _, f := range files {
+		df = append(df, vercel.DeploymentFile{File: f.Path, SHA: f.SHA, Size: f.Size, Mode: unixMode(f.Mode)})
+	}
+	return df
+}
+
+func hashFile(p string) (string, int64, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha1.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// unixMode converts a Go file mode to the unix st_mode bits Vercel expects.
+func unixMode(m fs.FileMode) uint32 {
+	mode := uint32(m.Perm())
+	if m&fs.ModeSymlink != 0 {
+		return mode | 0120000
+	}
+	return mode | 0100000
+}
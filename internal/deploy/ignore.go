@@ -0,0 +1,159 @@
+package deploy
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IGNORE_FILE_NAME is the file, at the root of the deployed directory, listing
+// gitignore style patterns of files that should not be uploaded.
+const IGNORE_FILE_NAME = ".vercelignore"
+
+// defaultIgnores are never uploaded, matching the behaviour of the Vercel CLI.
+var defaultIgnores = []string{
+	".hg",
+	".git",
+	".gitmodules",
+	".svn",
+	".cache",
+	".next/cache",
+	".vercel",
+	".npmrc",
+	".DS_Store",
+	".env.local",
+	".env.*.local",
+	"node_modules",
+	"npm-debug.log",
+	IGNORE_FILE_NAME,
+}
+
+type ignorePattern struct {
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// Ignore matches slash separated paths, relative to the deployed directory,
+// against gitignore style patterns. Later patterns take precedence. The zero
+// Ignore matches nothing.
+type Ignore struct {
+	patterns []ignorePattern
+}
+
+// NewIgnore returns an Ignore for the default patterns plus any in lines.
+func NewIgnore(lines []string) *Ignore {
+	i := &Ignore{}
+	for _, l := range append(append([]string{}, defaultIgnores...), lines...) {
+		i.add(l)
+	}
+	return i
+}
+
+// LoadIgnore reads the .vercelignore file in dir, if there is one.
+func LoadIgnore(dir string) (*Ignore, error) {
+	f, err := os.Open(filepath.Join(dir, IGNORE_FILE_NAME))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return NewIgnore(nil), nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewIgnore(lines), nil
+}
+
+func (i *Ignore) add(line string) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+
+	p := ignorePattern{}
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	// A pattern containing a slash, other than a trailing one, is relative to the root
+	if strings.Contains(line, "/") {
+		p.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+	p.pattern = line
+
+	i.patterns = append(i.patterns, p)
+}
+
+// Match reports whether the path p should be ignored.
+func (i *Ignore) Match(p string, isDir bool) bool {
+	ignored := false
+	for _, pattern := range i.patterns {
+		if pattern.dirOnly && !isDir {
+			continue
+		}
+		if pattern.matches(p) {
+			ignored = !pattern.negate
+		}
+	}
+	return ignored
+}
+
+func (p ignorePattern) matches(name string) bool {
+	if p.anchored {
+		return globMatch(p.pattern, name)
+	}
+	// Unanchored patterns match against any trailing part of the path
+	segments := strings.Split(name, "/")
+	for i := range segments {
+		if globMatch(p.pattern, strings.Join(segments[i:], "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// globMatch matches name against pattern, where "**" matches any number of
+// whole path segments and the other wildcards behave like path.Match.
+func globMatch(pattern, name string) bool {
+	patternParts := strings.Split(pattern, "/")
+	nameParts := strings.Split(name, "/")
+	return matchParts(patternParts, nameParts)
+}
+
+func matchParts(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchParts(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
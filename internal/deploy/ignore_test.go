@@ -0,0 +1,48 @@
+package deploy_test
+
+import (
+	"testing"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/deploy"
+)
+
+func TestIgnoreMatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{name: "default ignores", path: "node_modules", isDir: true, want: true},
+		{name: "default ignores nested", path: "packages/web/.git", isDir: true, want: true},
+		{name: "default local env", path: ".env.production.local", want: true},
+		{name: "not ignored", path: "src/index.js", want: false},
+		{name: "unanchored name", lines: []string{"*.log"}, path: "logs/build.log", want: true},
+		{name: "anchored with a leading slash", lines: []string{"/build"}, path: "build", isDir: true, want: true},
+		{name: "anchored doesn't match deeper", lines: []string{"/build"}, path: "src/build", isDir: true, want: false},
+		{name: "anchored by an inner slash", lines: []string{"docs/*.md"}, path: "docs/intro.md", want: true},
+		{name: "directory pattern matches a directory", lines: []string{"tmp/"}, path: "tmp", isDir: true, want: true},
+		{name: "directory pattern skips files", lines: []string{"tmp/"}, path: "tmp", want: false},
+		{name: "double star", lines: []string{"**/fixtures/**"}, path: "a/b/fixtures/c/d.json", want: true},
+		{name: "negation", lines: []string{"*.md", "!README.md"}, path: "README.md", want: false},
+		{name: "negation keeps others ignored", lines: []string{"*.md", "!README.md"}, path: "CHANGELOG.md", want: true},
+		{name: "later pattern wins", lines: []string{"!keep.txt", "*.txt"}, path: "keep.txt", want: true},
+		{name: "negating a default", lines: []string{"!.npmrc"}, path: ".npmrc", want: false},
+		{name: "comments and blank lines", lines: []string{"# *.js", "", "  "}, path: "app.js", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deploy.NewIgnore(tt.lines).Match(tt.path, tt.isDir); got != tt.want {
+				t.Errorf("Match(%q, %v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZeroIgnoreMatchesNothing(t *testing.T) {
+	var i deploy.Ignore
+	if i.Match("node_modules", true) {
+		t.Error("the zero Ignore matched node_modules")
+	}
+}
@@ -0,0 +1,245 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BUILD_OUTPUT_DIR is where the Build Output API expects prebuilt output,
+// relative to the project directory.
+const BUILD_OUTPUT_DIR = ".vercel/output"
+
+// BUILD_OUTPUT_VERSION is the supported version of the Build Output API.
+const BUILD_OUTPUT_VERSION = 3
+
+// SchemaError describes a single problem with a Build Output API directory.
+type SchemaError struct {
+	Path string // slash separated path relative to the output directory
+	Msg  string
+}
+
+func (e SchemaError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Msg)
+}
+
+// BuildOutputError lists every problem found in a Build Output API directory.
+type BuildOutputError struct {
+	Dir    string
+	Errors []SchemaError
+}
+
+func (e *BuildOutputError) Error() string {
+	lines := []string{fmt.Sprintf("invalid build output in %s:", e.Dir)}
+	for _, s := range e.Errors {
+		lines = append(lines, "  "+s.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+type buildOutputConfig struct {
+	Version   *int              `json:"version"`
+	Routes    []json.RawMessage `json:"routes"`
+	Overrides map[string]struct {
+		Path        string `json:"path"`
+		ContentType string `json:"contentType"`
+	} `json:"overrides"`
+	Crons []struct {
+		Path     string `json:"path"`
+		Schedule string `json:"schedule"`
+	} `json:"crons"`
+}
+
+type buildOutputRoute struct {
+	Src    *string `json:"src"`
+	Handle *string `json:"handle"`
+}
+
+type functionConfig struct {
+	Runtime    string `json:"runtime"`
+	Handler    string `json:"handler"`
+	Entrypoint string `json:"entrypoint"`
+}
+
+// ValidateBuildOutput checks that dir follows the layout of the Build Output
+// API v3, returning a *BuildOutputError listing every problem found.
+func ValidateBuildOutput(dir string) error {
+	v := &buildOutputValidator{dir: dir}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("No build output found at %s, build the project before using --prebuilt", dir)
+		}
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+
+	v.validateConfig()
+	v.validateStatic()
+	v.validateFunctions("functions")
+
+	if len(v.errors) > 0 {
+		return &BuildOutputError{Dir: dir, Errors: v.errors}
+	}
+	return nil
+}
+
+type buildOutputValidator struct {
+	dir    string
+	errors []SchemaError
+}
+
+func (v *buildOutputValidator) fail(p string, format string, a ...any) {
+	v.errors = append(v.errors, SchemaError{Path: p, Msg: fmt.Sprintf(format, a...)})
+}
+
+func (v *buildOutputValidator) path(p string) string {
+	return filepath.Join(v.dir, filepath.FromSlash(p))
+}
+
+func (v *buildOutputValidator) validateConfig() {
+	data, err := os.ReadFile(v.path("config.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			v.fail("config.json", "missing, it is required by the Build Output API")
+		} else {
+			v.fail("config.json", "%s", err)
+		}
+		return
+	}
+
+	var config buildOutputConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		v.fail("config.json", "invalid JSON: %s", err)
+		return
+	}
+
+	if config.Version == nil {
+		v.fail("config.json", "missing \"version\"")
+	} else if *config.Version != BUILD_OUTPUT_VERSION {
+		v.fail("config.json", "unsupported version %d, expected %d", *config.Version, BUILD_OUTPUT_VERSION)
+	}
+
+	for i, raw := range config.Routes {
+		var route buildOutputRoute
+		if err := json.Unmarshal(raw, &route); err != nil {
+			v.fail("config.json", "routes[%d] must be an object", i)
+			continue
+		}
+		if route.Src == nil && route.Handle == nil {
+			v.fail("config.json", "routes[%d] needs either \"src\" or \"handle\"", i)
+		}
+	}
+
+	for p, o := range config.Overrides {
+		if _, err := os.Stat(v.path(path.Join("static", p))); err != nil {
+			v.fail("config.json", "overrides[%q] refers to a missing static file", p)
+		}
+		if o.Path == "" && o.ContentType == "" {
+			v.fail("config.json", "overrides[%q] needs a \"path\" or \"contentType\"", p)
+		}
+	}
+
+	for i, c := range config.Crons {
+		if !strings.HasPrefix(c.Path, "/") {
+			v.fail("config.json", "crons[%d].path must start with /", i)
+		}
+		if len(strings.Fields(c.Schedule)) != 5 {
+			v.fail("config.json", "crons[%d].schedule must be a 5 field cron expression", i)
+		}
+	}
+}
+
+func (v *buildOutputValidator) validateStatic() {
+	info, err := os.Stat(v.path("static"))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			v.fail("static", "%s", err)
+		}
+		return
+	}
+	if !info.IsDir() {
+		v.fail("static", "must be a directory")
+	}
+}
+
+// validateFunctions checks every .func directory under p, recursing into
+// the plain directories that make up function paths.
+func (v *buildOutputValidator) validateFunctions(p string) {
+	entries, err := os.ReadDir(v.path(p))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			v.fail(p, "%s", err)
+		}
+		return
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	for _, e := range entries {
+		ep := path.Join(p, e.Name())
+		info, err := os.Stat(v.path(ep))
+		if err != nil {
+			v.fail(ep, "%s", err)
+			continue
+		}
+
+		switch {
+		case strings.HasSuffix(e.Name(), ".func"):
+			if !info.IsDir() {
+				v.fail(ep, "functions must be directories")
+				continue
+			}
+			v.validateFunction(ep)
+		case strings.HasSuffix(e.Name(), ".prerender-config.json"),
+			strings.Contains(e.Name(), ".prerender-fallback."):
+			// prerender configuration sits alongside its function
+		case info.IsDir():
+			v.validateFunctions(ep)
+		default:
+			v.fail(ep, "unexpected file, functions must be .func directories")
+		}
+	}
+}
+
+func (v *buildOutputValidator) validateFunction(p string) {
+	configPath := path.Join(p, ".vc-config.json")
+	data, err := os.ReadFile(v.path(configPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			v.fail(configPath, "missing, every function needs a .vc-config.json")
+		} else {
+			v.fail(configPath, "%s", err)
+		}
+		return
+	}
+
+	var config functionConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		v.fail(configPath, "invalid JSON: %s", err)
+		return
+	}
+
+	entry := config.Handler
+	field := "handler"
+	if config.Runtime == "edge" {
+		entry = config.Entrypoint
+		field = "entrypoint"
+	} else if config.Runtime == "" {
+		v.fail(configPath, "missing \"runtime\"")
+	}
+
+	if entry == "" {
+		v.fail(configPath, "missing %q", field)
+		return
+	}
+	if _, err := os.Stat(v.path(path.Join(p, entry))); err != nil {
+		v.fail(configPath, "%s %q does not exist in the function", field, entry)
+	}
+}
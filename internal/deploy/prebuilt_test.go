@@ -0,0 +1,124 @@
+package deploy_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/deploy"
+)
+
+// writeFiles creates files in dir, by slash separated path.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for p, content := range files {
+		p = filepath.Join(dir, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestValidateBuildOutput(t *testing.T) {
+	const config = `{"version": 3}`
+	const function = `{"runtime": "nodejs20.x", "handler": "index.js"}`
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string // the errors expected, as "path: message" prefixes
+	}{
+		{
+			name: "valid",
+			files: map[string]string{
+				"config.json":       `{"version": 3, "routes": [{"handle": "filesystem"}], "crons": [{"path": "/api/cron", "schedule": "0 * * * *"}]}`,
+				"static/index.html": "<html>",
+				"functions/api/hello.func/.vc-config.json": function,
+				"functions/api/hello.func/index.js":        "",
+				"functions/edge.func/.vc-config.json":      `{"runtime": "edge", "entrypoint": "e.js"}`,
+				"functions/edge.func/e.js":                 "",
+			},
+		},
+		{name: "missing config", files: map[string]string{"static/a.txt": ""}, want: []string{"config.json: missing"}},
+		{name: "missing version", files: map[string]string{"config.json": `{}`}, want: []string{`config.json: missing "version"`}},
+		{name: "wrong version", files: map[string]string{"config.json": `{"version": 2}`}, want: []string{"config.json: unsupported version 2"}},
+		{name: "invalid JSON", files: map[string]string{"config.json": `{`}, want: []string{"config.json: invalid JSON"}},
+		{
+			name:  "bad route and cron",
+			files: map[string]string{"config.json": `{"version": 3, "routes": [{}, 1], "crons": [{"path": "api", "schedule": "* *"}]}`},
+			want: []string{
+				`config.json: routes[0] needs either "src" or "handle"`,
+				"config.json: routes[1] must be an object",
+				"config.json: crons[0].path must start with /",
+				"config.json: crons[0].schedule must be a 5 field cron expression",
+			},
+		},
+		{
+			name:  "override of a missing file",
+			files: map[string]string{"config.json": `{"version": 3, "overrides": {"about.html": {"path": "about"}}}`},
+			want:  []string{`config.json: overrides["about.html"] refers to a missing static file`},
+		},
+		{
+			name:  "function without a config",
+			files: map[string]string{"config.json": config, "functions/a.func/index.js": ""},
+			want:  []string{"functions/a.func/.vc-config.json: missing"},
+		},
+		{
+			name:  "function with a missing handler",
+			files: map[string]string{"config.json": config, "functions/a.func/.vc-config.json": function},
+			want:  []string{`functions/a.func/.vc-config.json: handler "index.js" does not exist`},
+		},
+		{
+			name:  "function without a runtime",
+			files: map[string]string{"config.json": config, "functions/a.func/.vc-config.json": `{"handler": "i.js"}`, "functions/a.func/i.js": ""},
+			want:  []string{`functions/a.func/.vc-config.json: missing "runtime"`},
+		},
+		{
+			name:  "stray file among functions",
+			files: map[string]string{"config.json": config, "functions/api/readme.txt": ""},
+			want:  []string{"functions/api/readme.txt: unexpected file"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			err := deploy.ValidateBuildOutput(dir)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("ValidateBuildOutput() = %v, want nil", err)
+				}
+				return
+			}
+			var boErr *deploy.BuildOutputError
+			if !errors.As(err, &boErr) {
+				t.Fatalf("ValidateBuildOutput() = %v, want a *BuildOutputError", err)
+			}
+			var got []string
+			for _, e := range boErr.Errors {
+				got = append(got, e.Error())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got errors %q, want %q", got, tt.want)
+			}
+			for _, want := range tt.want {
+				if !slices.ContainsFunc(got, func(g string) bool { return strings.HasPrefix(g, want) }) {
+					t.Errorf("got errors %q, want one starting with %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateBuildOutputMissingDir(t *testing.T) {
+	err := deploy.ValidateBuildOutput(filepath.Join(t.TempDir(), "output"))
+	if err == nil || !strings.Contains(err.Error(), "No build output found") {
+		t.Errorf("ValidateBuildOutput() = %v, want a missing output error", err)
+	}
+}
@@ -0,0 +1,107 @@
+package deploy
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+// DEFAULT_CONCURRENCY is the number of files uploaded at once.
+const DEFAULT_CONCURRENCY = 8
+
+// Upload uploads the files whose SHA is in shas, concurrency at a time, while
+// drawing progress bars to out. Files sharing content are uploaded once.
+func Upload(v *vercel.VercelAPI, files []LocalFile, shas []string, concurrency int, out io.Writer) error {
+	if concurrency < 1 {
+		concurrency = DEFAULT_CONCURRENCY
+	}
+
+	bySHA := map[string]LocalFile{}
+	for _, f := range files {
+		bySHA[f.SHA] = f
+	}
+
+	var pending []LocalFile
+	var total int64
+	for _, sha := range shas {
+		f, ok := bySHA[sha]
+		if !ok {
+			return fmt.Errorf("Vercel requested unknown file %s", sha)
+		}
+		pending = append(pending, f)
+		total += f.Size
+		delete(bySHA, sha)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	if concurrency > len(pending) {
+		concurrency = len(pending)
+	}
+
+	progress := menu.NewProgress(out, fmt.Sprintf("Uploading %d files", len(pending)), total, concurrency)
+	defer progress.Close()
+
+	queue := make(chan LocalFile)
+	// The first upload to fail stops the rest, closing done to stop queueing
+	var firstErr error
+	var failOnce sync.Once
+	done := make(chan struct{})
+	fail := func(err error) {
+		failOnce.Do(func() {
+			firstErr = err
+			close(done)
+		})
+	}
+
+	var wg sync.WaitGroup
+	for slot := 0; slot < concurrency; slot++ {
+		wg.Add(1)
+		go func(slot int) {
+			defer wg.Done()
+			for f := range queue {
+				progress.Start(slot, path.Base(f.Path), f.Size)
+				err := uploadFile(v, f, progress.Writer(slot))
+				progress.Finish(slot)
+				if err != nil {
+					fail(fmt.Errorf("uploading %s: %w", f.Path, err))
+					return
+				}
+			}
+		}(slot)
+	}
+
+	go func() {
+		defer close(queue)
+		for _, f := range pending {
+			select {
+			case queue <- f:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	return firstErr
+}
+
+func uploadFile(v *vercel.VercelAPI, f LocalFile, progress io.Writer) error {
+	if f.Symlink != "" {
+		return v.UploadFile(f.SHA, f.Size, io.TeeReader(strings.NewReader(f.Symlink), progress))
+	}
+
+	file, err := os.Open(f.AbsPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return v.UploadFile(f.SHA, f.Size, io.TeeReader(file, progress))
+}
@@ -1,10 +1,12 @@
 package environment
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
 
+	"github.com/joho/godotenv"
 	"github.com/m87wheeler/golang-vercel-cli/pkg/utils"
 )
 
@@ -70,6 +72,16 @@ func (e *Environment) Load() {
 
 }
 
+// StateDir returns the directory holding the .env file, where the CLI also
+// keeps what it records between runs.
+func (e *Environment) StateDir() (string, error) {
+	homeDir, err := utils.GetHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return homeDir + "/" + e.EnvFileDir, nil
+}
+
 func (e *Environment) Configure() {
 	r := utils.Reader()
 	teamId, err := utils.UserInput(r, "Enter your Vercel team ID", false)
@@ -127,3 +139,29 @@ func (e *Environment) writeEnvFile() (string, error) {
 
 	return fp, nil
 }
+
+// Set saves a setting to the .env file, keeping the settings already in it,
+// and applies it to the running process.
+func (e *Environment) Set(key, value string) error {
+	if e.EnvLoadFrom == "" {
+		return errors.New("no env file to save settings to, run the CLI once to create it")
+	}
+
+	settings, err := godotenv.Read(e.EnvLoadFrom)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		delete(settings, key)
+	} else {
+		settings[key] = value
+	}
+
+	if err := godotenv.Write(settings, e.EnvLoadFrom); err != nil {
+		return err
+	}
+	if value == "" {
+		return os.Unsetenv(key)
+	}
+	return os.Setenv(key, value)
+}
@@ -0,0 +1,162 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/screens"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/utils"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+// ScreenAction is a single key action on the deployment under the cursor of a
+// screen's list, run without opening the deployment first.
+type ScreenAction struct {
+	Key         string
+	Label       string // a word or two for the key hints
+	Description string // a sentence for the help
+	// Suspend hides the list while Run asks for confirmation or prints output
+	Suspend bool
+	// Run acts on the deployment, as listed, printing any output to t, and
+	// returns a message shown below the list
+	Run func(v *vercel.VercelAPI, d vercel.DeploymentData, t menu.Terminal) (string, error)
+}
+
+// screenActions are the quick actions of each screen's list, by screen name.
+var screenActions = map[string][]ScreenAction{
+	screens.DEPLOYMENTS: {
+		{Key: "c", Label: "cancel", Description: "Cancel the deployment", Suspend: true, Run: cancelAction},
+		{Key: "r", Label: "redeploy", Description: "Redeploy the deployment", Suspend: true, Run: redeployAction},
+		{Key: "o", Label: "open", Description: "Open the deployment in the browser", Run: openAction},
+		{Key: "y", Label: "copy", Description: "Copy the deployment's URL", Run: copyAction},
+		{Key: "l", Label: "logs", Description: "Show the deployment's build log", Suspend: true, Run: logsAction},
+		{Key: "i", Label: "inspect", Description: "Show the deployment's details", Suspend: true, Run: inspectAction},
+	},
+}
+
+// RegisterScreenAction adds a quick action to the list shown by screen. Its
+// key takes precedence over the menu keymap.
+func RegisterScreenAction(screen string, a ScreenAction) {
+	screenActions[screen] = append(screenActions[screen], a)
+}
+
+// addScreenActions adds the quick actions registered for screen to m, whose
+// items hold the deployments they were made from.
+func addScreenActions(m *menu.Menu, screen string, v *vercel.VercelAPI) {
+	for _, a := range screenActions[screen] {
+		m.AddAction(menu.MenuAction{
+			Key:         a.Key,
+			Label:       a.Label,
+			Description: a.Description,
+			Suspend:     a.Suspend,
+			Run: func(item *menu.MenuItem) string {
+				d, ok := item.Value.(vercel.DeploymentData)
+				if !ok {
+					return "Not a deployment"
+				}
+				message, err := a.Run(v, d, m.Terminal)
+				switch {
+				case errors.Is(err, ErrNotConfirmed):
+					return err.Error()
+				case err != nil:
+					return "Error: " + err.Error()
+				}
+				return message
+			},
+		})
+	}
+}
+
+// fullDeployment fetches every detail of a listed deployment, which the list
+// leaves out.
+func fullDeployment(v *vercel.VercelAPI, d vercel.DeploymentData) (vercel.DeploymentData, error) {
+	full, err := v.GetDeployment(d.UID)
+	if err != nil {
+		return full, fmt.Errorf("fetching %s: %w", d.UID, err)
+	}
+	return full, nil
+}
+
+func cancelAction(v *vercel.VercelAPI, d vercel.DeploymentData, t menu.Terminal) (string, error) {
+	full, err := fullDeployment(v, d)
+	if err != nil {
+		return "", err
+	}
+	if err := DeploymentAction(v, string(vercel.CANCEL), full.ID, full); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Cancelled %s", full.URL), nil
+}
+
+func redeployAction(v *vercel.VercelAPI, d vercel.DeploymentData, t menu.Terminal) (string, error) {
+	full, err := fullDeployment(v, d)
+	if err != nil {
+		return "", err
+	}
+	if err := DeploymentAction(v, string(vercel.REDEPLOY), full.ID, full); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Redeploying %s", full.Name), nil
+}
+
+func openAction(v *vercel.VercelAPI, d vercel.DeploymentData, t menu.Terminal) (string, error) {
+	url, err := DeploymentURL(d, false, 0)
+	if err != nil {
+		return "", err
+	}
+	if err := utils.OpenBrowser(url); err != nil {
+		return "", err
+	}
+	return "Opened " + url, nil
+}
+
+func copyAction(v *vercel.VercelAPI, d vercel.DeploymentData, t menu.Terminal) (string, error) {
+	url, err := DeploymentURL(d, false, 0)
+	if err != nil {
+		return "", err
+	}
+	if err := utils.CopyToClipboard(url); err != nil {
+		return "", err
+	}
+	return "Copied " + url, nil
+}
+
+func logsAction(v *vercel.VercelAPI, d vercel.DeploymentData, t menu.Terminal) (string, error) {
+	events, err := v.GetDeploymentEvents(d.UID, dashboardLogLines)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(t, "Build log of %s\n", d.URL)
+	if len(events) == 0 {
+		fmt.Fprintln(t, "  No build output")
+	}
+	for _, line := range FormatBuildLog(events) {
+		fmt.Fprintln(t, line)
+	}
+	fmt.Fprintln(t)
+	return fmt.Sprintf("Showed the build log of %s above", d.URL), nil
+}
+
+func inspectAction(v *vercel.VercelAPI, d vercel.DeploymentData, t menu.Terminal) (string, error) {
+	full, err := fullDeployment(v, d)
+	if err != nil {
+		return "", err
+	}
+	m := menu.NewMenu("")
+	m.Terminal = t
+	m.DisplayInfoSections(FormatDeploymentDetails(full, DeploymentErrorLog(v, full)))
+	return fmt.Sprintf("Showed the details of %s above", full.URL), nil
+}
+
+// FormatBuildLog returns the lines of a deployment's build log, indented.
+func FormatBuildLog(events []vercel.DeploymentEvent) []string {
+	var lines []string
+	for _, e := range events {
+		for _, line := range strings.Split(strings.TrimRight(e.Message(), "\n"), "\n") {
+			lines = append(lines, "  "+line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,181 @@
+package helpers
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/environment"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/utils"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+// DeploymentColumn is a column the deployments list can show.
+type DeploymentColumn struct {
+	Name    string
+	Value   func(d vercel.DeploymentData) string
+	Compare func(a, b vercel.DeploymentData) int // defaults to comparing the values
+}
+
+// DeploymentColumns are the columns the deployments list can show, in the
+// order they are offered.
+var DeploymentColumns = []DeploymentColumn{
+	{Name: "name", Value: func(d vercel.DeploymentData) string { return d.Name }},
+	{Name: "creator", Value: func(d vercel.DeploymentData) string { return d.Creator.Username }},
+	{Name: "branch", Value: func(d vercel.DeploymentData) string { return d.Meta.CommitRef }},
+	{
+		Name:  "age",
+		Value: func(d vercel.DeploymentData) string { return utils.ElapsedTime(d.CreatedTime().Unix()) },
+		// Newest first
+		Compare: func(a, b vercel.DeploymentData) int { return b.CreatedTime().Compare(a.CreatedTime()) },
+	},
+	{Name: "state", Value: func(d vercel.DeploymentData) string { return d.ReadyState }},
+	{Name: "url", Value: func(d vercel.DeploymentData) string { return d.URL }},
+	{Name: "target", Value: deploymentTarget},
+	{Name: "sha", Value: func(d vercel.DeploymentData) string { return shortSHA(d.Meta.CommitSHA) }},
+	{
+		Name:    "queued",
+		Value:   func(d vercel.DeploymentData) string { return formatTiming(d, queuedTime) },
+		Compare: compareTiming(queuedTime),
+	},
+	{
+		Name:    "duration",
+		Value:   func(d vercel.DeploymentData) string { return formatTiming(d, buildTime) },
+		Compare: compareTiming(buildTime),
+	},
+	{
+		Name:    "total",
+		Value:   func(d vercel.DeploymentData) string { return formatTiming(d, totalTime) },
+		Compare: compareTiming(totalTime),
+	},
+	{Name: "source", Value: func(d vercel.DeploymentData) string { return d.Source }},
+}
+
+// DEFAULT_DEPLOYMENT_COLUMNS are shown when DEPLOYMENT_COLUMNS isn't set.
+var DEFAULT_DEPLOYMENT_COLUMNS = []string{"name", "creator", "branch", "age", "state"}
+
+// DeploymentColumnNames returns the names of every column the deployments list can show.
+func DeploymentColumnNames() []string {
+	names := make([]string, len(DeploymentColumns))
+	for i, c := range DeploymentColumns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// ParseDeploymentColumns returns the named columns, in the order given.
+func ParseDeploymentColumns(names []string) ([]DeploymentColumn, error) {
+	var columns []DeploymentColumn
+	for _, n := range names {
+		n = strings.ToLower(strings.TrimSpace(n))
+		found := false
+		for _, c := range DeploymentColumns {
+			if c.Name == n {
+				columns = append(columns, c)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column %q, choose from: %s", n, strings.Join(DeploymentColumnNames(), ", "))
+		}
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("choose at least one column from: %s", strings.Join(DeploymentColumnNames(), ", "))
+	}
+	return columns, nil
+}
+
+// SelectedDeploymentColumns returns the columns chosen in DEPLOYMENT_COLUMNS,
+// or the default columns when it isn't set or isn't valid.
+func SelectedDeploymentColumns() []DeploymentColumn {
+	if names := os.Getenv("DEPLOYMENT_COLUMNS"); names != "" {
+		if columns, err := ParseDeploymentColumns(strings.Split(names, ",")); err == nil {
+			return columns
+		}
+	}
+	columns, _ := ParseDeploymentColumns(DEFAULT_DEPLOYMENT_COLUMNS)
+	return columns
+}
+
+// SaveDeploymentColumns saves the columns the deployments list shows to the
+// config. No names restores the default columns.
+func SaveDeploymentColumns(e *environment.Environment, names []string) error {
+	if len(names) > 0 {
+		if _, err := ParseDeploymentColumns(names); err != nil {
+			return err
+		}
+	}
+	return e.Set("DEPLOYMENT_COLUMNS", strings.Join(names, ","))
+}
+
+// deploymentTable sets up m to show deployments as a table of the selected columns.
+func deploymentTable(m *menu.Menu) {
+	m.Columns = nil
+	for _, c := range SelectedDeploymentColumns() {
+		column := menu.Column{Name: strings.ToUpper(c.Name[:1]) + c.Name[1:]}
+		if c.Compare != nil {
+			compare := c.Compare
+			column.Compare = func(a, b *menu.MenuItem) int {
+				return compare(a.Value.(vercel.DeploymentData), b.Value.(vercel.DeploymentData))
+			}
+		}
+		m.Columns = append(m.Columns, column)
+	}
+}
+
+// deploymentItems returns the deployments as rows of the selected columns,
+// which flash when their state changes.
+func deploymentItems(dl vercel.DeploymentsList) []*menu.MenuItem {
+	columns := SelectedDeploymentColumns()
+	items := make([]*menu.MenuItem, 0, len(dl.Deployments))
+	for _, d := range dl.Deployments {
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = c.Value(d)
+		}
+		items = append(items, &menu.MenuItem{ID: d.UID, Cells: cells, State: d.ReadyState, Value: d})
+	}
+	return items
+}
+
+func deploymentTarget(d vercel.DeploymentData) string {
+	if d.Target == "" {
+		return string(vercel.PREVIEW)
+	}
+	return d.Target
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// formatTiming formats one of the durations of a deployment's timing,
+// marking it with "+" while the deployment is still running.
+func formatTiming(d vercel.DeploymentData, duration func(t vercel.Timing) time.Duration) string {
+	t := d.Timing(utils.Now())
+	dur := duration(t)
+	if dur <= 0 {
+		return ""
+	}
+	if t.Running {
+		return utils.FormatDuration(dur) + "+"
+	}
+	return utils.FormatDuration(dur)
+}
+
+func queuedTime(t vercel.Timing) time.Duration { return t.Queued }
+func buildTime(t vercel.Timing) time.Duration  { return t.Build }
+func totalTime(t vercel.Timing) time.Duration  { return t.Total }
+
+func compareTiming(duration func(t vercel.Timing) time.Duration) func(a, b vercel.DeploymentData) int {
+	return func(a, b vercel.DeploymentData) int {
+		now := utils.Now()
+		return cmp.Compare(duration(a.Timing(now)), duration(b.Timing(now)))
+	}
+}
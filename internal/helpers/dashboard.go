@@ -0,0 +1,312 @@
+package helpers
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/screens"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+// dashboardLogLines is how many build log lines the details pane shows
+const dashboardLogLines = 100
+
+// dashboardTick is how often the dashboard applies what was loaded in the
+// background
+const dashboardTick = 250 * time.Millisecond
+
+// dashboard holds the panes of the dashboard screen and what they show.
+type dashboard struct {
+	v      *vercel.VercelAPI
+	states []string
+	layout *menu.Layout
+
+	projects    *menu.Menu
+	deployments *menu.Menu
+	details     *menu.TextPane
+
+	project    string // the project the deployments were fetched for
+	deployment vercel.DeploymentData
+	list       vercel.DeploymentsList
+	updated    time.Time
+	err        error
+	chosen     string // the deployment opened with Enter
+
+	// Deployments and details are fetched in the background, and applied on
+	// the next tick
+	loads     chan dashboardLoad
+	stop      chan struct{}
+	listing   string    // the project whose deployments are being fetched
+	loading   string    // the deployment whose details are being fetched
+	requested time.Time // when the deployments were last fetched
+}
+
+// dashboardLoad is what a background fetch loaded: the deployments of a
+// project, or the details of a deployment.
+type dashboardLoad struct {
+	project    string
+	list       vercel.DeploymentsList
+	id         string
+	deployment vercel.DeploymentData
+	lines      []string
+	err        error
+}
+
+// followPane updates the dashboard after each key handled by a menu, so the
+// other panes follow its cursor.
+type followPane struct {
+	*menu.Menu
+	d *dashboard
+}
+
+func (p followPane) HandleKey(key menu.Key) bool {
+	changed := p.Menu.HandleKey(key)
+	p.d.follow()
+	return changed
+}
+
+// RenderDashboardScreen shows projects, their deployments and the details and
+// build log of the selected deployment in a full-screen layout, refreshing
+// the deployments as they change. Enter on a deployment opens its actions.
+func RenderDashboardScreen(args screens.RenderDashboardArgs) screens.Result[screens.DashboardResult] {
+	d := &dashboard{
+		v:           args.VercelAPI,
+		states:      args.States,
+		projects:    menu.NewMenu("Projects"),
+		deployments: menu.NewMenu("Deployments"),
+		details:     menu.NewTextPane(),
+		loads:       make(chan dashboardLoad),
+		stop:        make(chan struct{}),
+	}
+	defer close(d.stop)
+	deploymentTable(d.deployments)
+	if len(d.states) == 0 {
+		for _, s := range vercel.DeploymentStates {
+			d.states = append(d.states, string(s))
+		}
+	}
+
+	for i, n := range slices.Sorted(maps.Keys(args.Env.Projects)) {
+		d.projects.AddItem(n, n)
+		if n == args.ProjectName {
+			d.projects.CursorPos = i
+		}
+	}
+
+	d.layout = menu.NewLayout(
+		&menu.Region{Title: "Projects", Pane: followPane{d.projects, d}},
+		&menu.Region{Title: "Deployments", Pane: followPane{d.deployments, d}},
+		&menu.Region{Title: "Details", Pane: d.details},
+	)
+	d.layout.Arrange = d.arrange
+	d.layout.Status = d.status
+	d.layout.Update = d.update
+	d.layout.Actions = []menu.MenuAction{
+		{Key: menu.KEY_ENTER, Label: "open", Description: "Open the actions of the deployment under the cursor"},
+		{Key: "r", Label: "refresh", Description: "Refresh the deployments now"},
+		{Key: "q", Label: "quit", Description: "Close the dashboard"},
+	}
+	d.layout.Tick = dashboardTick
+	if args.ProjectName != "" {
+		d.layout.Focus = 1
+	}
+
+	d.follow()
+	if err := d.layout.Run(); err != nil {
+		return screens.Result[screens.DashboardResult]{Err: err}
+	}
+	if d.chosen == "" {
+		return screens.Result[screens.DashboardResult]{Back: true}
+	}
+	return screens.Result[screens.DashboardResult]{
+		Data: screens.DashboardResult{ProjectName: d.project, DeploymentID: d.chosen},
+	}
+}
+
+// arrange puts the projects in a column on the left, with the deployments
+// above the details on the right.
+func (d *dashboard) arrange(width, height int) {
+	projects, deployments, details := d.layout.Regions[0], d.layout.Regions[1], d.layout.Regions[2]
+
+	left := min(max(width/5, 16), 32)
+	top := max(height*2/5, 5)
+
+	projects.X, projects.Y, projects.Width, projects.Height = 0, 0, left, height
+	deployments.X, deployments.Y, deployments.Width, deployments.Height = left, 0, width-left, top
+	details.X, details.Y, details.Width, details.Height = left, top, width-left, height-top
+}
+
+func (d *dashboard) status() string {
+	status := fmt.Sprintf(" %s  %s", d.project, d.layout.Hints())
+	if d.listing != "" || d.loading != "" {
+		status += "  Loading..."
+	}
+	if d.err != nil {
+		return status + "  Error: " + d.err.Error()
+	}
+	if !d.updated.IsZero() {
+		status += "  Updated " + d.updated.Format(time.TimeOnly)
+	}
+	return status
+}
+
+func (d *dashboard) update(key menu.Key) (bool, bool) {
+	switch {
+	case key.Name == menu.KEY_TICK:
+		d.apply()
+		if RefreshInterval > 0 && time.Since(d.requested) >= RefreshInterval {
+			d.refresh()
+		}
+		return true, false
+	case key.Rune == 'r':
+		d.refresh()
+		return true, false
+	case key.Rune == 'q' || d.layout.Keymap[key.Name] == menu.ACTION_BACK:
+		return true, true
+	case d.layout.Keymap[key.Name] == menu.ACTION_SELECT:
+		if d.layout.Focus == 0 {
+			d.layout.Focus = 1
+			return true, false
+		}
+		d.chosen = d.deployments.Current()
+		return true, d.chosen != ""
+	}
+	return false, false
+}
+
+// follow loads the deployments of the project under the cursor, and the
+// details of the deployment under the cursor, when either has moved.
+func (d *dashboard) follow() {
+	if project := d.projects.Current(); project != d.project {
+		d.project = project
+		d.list = vercel.DeploymentsList{}
+		d.deployments.SetItems(nil)
+		d.refresh()
+	}
+	if id := d.deployments.Current(); id != d.deployment.ID {
+		d.loadDetails(id)
+	}
+}
+
+// refresh refetches the deployments in the background, unless they are
+// already being fetched.
+func (d *dashboard) refresh() {
+	if d.project == "" || d.listing == d.project {
+		return
+	}
+	d.listing = d.project
+	d.requested = time.Now()
+
+	project, states := d.project, d.states
+	go func() {
+		list, err := d.v.GetDeployments(project, 20, 24, states)
+		d.send(dashboardLoad{project: project, list: list, err: err})
+	}()
+}
+
+// loadDetails fetches the details and build log of a deployment in the
+// background, unless they are already being fetched.
+func (d *dashboard) loadDetails(id string) {
+	if id == "" {
+		d.deployment = vercel.DeploymentData{}
+		d.details.SetLines(nil)
+		return
+	}
+	if d.loading == id {
+		return
+	}
+	d.loading = id
+
+	go func() {
+		dep, lines, err := dashboardDetails(d.v, id)
+		d.send(dashboardLoad{id: id, deployment: dep, lines: lines, err: err})
+	}()
+}
+
+// send passes a background fetch to the UI loop, dropping it once the
+// dashboard has closed.
+func (d *dashboard) send(l dashboardLoad) {
+	select {
+	case d.loads <- l:
+	case <-d.stop:
+	}
+}
+
+// apply shows everything loaded since the last tick. Loads for a project or
+// deployment that is no longer under the cursor are dropped.
+func (d *dashboard) apply() {
+	for {
+		select {
+		case l := <-d.loads:
+			if l.id == "" {
+				d.applyList(l)
+			} else {
+				d.applyDetails(l)
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (d *dashboard) applyList(l dashboardLoad) {
+	if l.project == d.listing {
+		d.listing = ""
+	}
+	if l.project != d.project {
+		return
+	}
+	d.err = l.err
+	if l.err != nil {
+		return
+	}
+	d.list = l.list
+	d.updated = time.Now()
+
+	d.deployments.SetItems(deploymentItems(l.list))
+
+	// Reload the selected deployment while it is still building
+	id := d.deployments.Current()
+	if id != d.deployment.ID || !IsFinalState(d.deployment.ReadyState) {
+		d.loadDetails(id)
+	}
+}
+
+func (d *dashboard) applyDetails(l dashboardLoad) {
+	if l.id == d.loading {
+		d.loading = ""
+	}
+	if l.id != d.deployments.Current() {
+		return
+	}
+	if l.err != nil {
+		d.err = l.err
+		return
+	}
+	d.deployment = l.deployment
+	d.details.SetLines(l.lines)
+}
+
+// dashboardDetails fetches a deployment and returns it with the lines of its
+// details and build log.
+func dashboardDetails(v *vercel.VercelAPI, id string) (vercel.DeploymentData, []string, error) {
+	dep, err := v.GetDeployment(id)
+	if err != nil {
+		return dep, nil, err
+	}
+
+	events, err := v.GetDeploymentEvents(id, dashboardLogLines)
+	var failed []string
+	if err == nil && dep.ReadyState == string(vercel.ERROR) {
+		failed = failedBuildLog(events)
+	}
+	lines := menu.FormatInfoSections(FormatDeploymentDetails(dep, failed))
+	if err == nil && len(events) > 0 {
+		lines = append(lines, "", "Build log")
+		lines = append(lines, FormatBuildLog(events)...)
+	}
+	return dep, lines, nil
+}
@@ -0,0 +1,228 @@
+package helpers
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/utils"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+// errorLogLines is how many lines of a failed build's log the Errors section shows
+const errorLogLines = 10
+
+// rows collects the rows of a section, leaving out empty values.
+type rows []menu.InfoTableData
+
+func (r *rows) add(label, value string) {
+	if value != "" {
+		*r = append(*r, menu.InfoTableData{Label: label, Data: value})
+	}
+}
+
+// addList adds a row per value, labelling the first.
+func (r *rows) addList(label string, values []string) {
+	for i, v := range values {
+		if i > 0 {
+			label = ""
+		}
+		*r = append(*r, menu.InfoTableData{Label: label, Data: v})
+	}
+}
+
+// FormatDeploymentDetails formats a deployment, fetched by ID, into the
+// sections of the detail view. errorLog holds the end of the build log of a
+// failed deployment, shown with why it failed.
+func FormatDeploymentDetails(d vercel.DeploymentData, errorLog []string) []menu.InfoSection {
+	var overview rows
+	overview.add("ID", d.ID)
+	overview.add("Name", d.Name)
+	overview.add("Creator", d.Creator.Username)
+	state := vercel.FormatStateString(d.ReadyState)
+	if d.ReadySubstate != "" {
+		state += " (" + strings.ToLower(d.ReadySubstate) + ")"
+	}
+	overview.add("State", state)
+	overview.add("Target", deploymentTarget(d))
+	if !d.CreatedTime().IsZero() {
+		overview.add("Created", utils.ElapsedTime(d.CreatedTime().Unix()))
+	}
+	if d.BuildingAt > 0 {
+		overview.add("Started", utils.ElapsedTime(int64(d.BuildingAt)/1000))
+	}
+	overview.add("URL", d.URL)
+	overview.add("Inspector", d.InspectorURL)
+
+	return []menu.InfoSection{
+		{Title: "Deployment", Rows: overview},
+		{Title: "Git", Rows: gitRows(d)},
+		{Title: "Build", Rows: buildRows(d)},
+		{Title: "Runtime", Rows: runtimeRows(d)},
+		{Title: "Aliases", Rows: aliasRows(d)},
+		{Title: "Errors", Rows: errorRows(d, errorLog)},
+	}
+}
+
+func gitRows(d vercel.DeploymentData) rows {
+	var r rows
+	c := d.Commit()
+	r.add("Repository", c.Repo)
+	r.add("Branch", c.Branch)
+	r.add("Commit", c.SHA)
+	r.add("Message", c.Subject())
+	r.add("Author", c.Author)
+	if c.PullRequest != "" {
+		r.add("Pull request", "#"+c.PullRequest)
+	}
+	r.add("Commit URL", c.URL())
+	return r
+}
+
+func buildRows(d vercel.DeploymentData) rows {
+	var r rows
+	s := d.ProjectSettings
+	r.add("Framework", s.Framework)
+	r.add("Install", s.InstallCommand)
+	r.add("Build", s.BuildCommand)
+	r.add("Output", s.OutputDirectory)
+	r.add("Root", s.RootDirectory)
+	r.add("Node.js", s.NodeVersion)
+	if d.BuildSkipped {
+		r.add("Skipped", "yes, the build step was ignored")
+	}
+	timingRows(&r, d.Timing(utils.Now()))
+	if d.ChecksState != "" {
+		checks := strings.ToLower(d.ChecksState)
+		if d.ChecksConclusion != "" {
+			checks += ", " + strings.ToLower(d.ChecksConclusion)
+		}
+		r.add("Checks", checks)
+	}
+	return r
+}
+
+// timingRows adds how long a deployment was queued and built for, marking
+// the durations of a deployment still running.
+func timingRows(r *rows, t vercel.Timing) {
+	format := func(d time.Duration) string {
+		if d <= 0 {
+			return ""
+		}
+		if t.Running {
+			return utils.FormatDuration(d) + " so far"
+		}
+		return utils.FormatDuration(d)
+	}
+	r.add("Queued", format(t.Queued))
+	r.add("Duration", format(t.Build))
+	r.add("Total", format(t.Total))
+}
+
+func runtimeRows(d vercel.DeploymentData) rows {
+	var r rows
+	r.add("Regions", strings.Join(d.Regions, ", "))
+	r.add("Built in", d.CreatedIn)
+
+	var functions []string
+	for _, pattern := range slices.Sorted(maps.Keys(d.Functions)) {
+		f := d.Functions[pattern]
+		var settings []string
+		if f.Runtime != "" {
+			settings = append(settings, f.Runtime)
+		}
+		if f.Memory > 0 {
+			settings = append(settings, fmt.Sprintf("%d MB", f.Memory))
+		}
+		if f.MaxDuration > 0 {
+			settings = append(settings, fmt.Sprintf("%ds max", f.MaxDuration))
+		}
+		functions = append(functions, strings.TrimSpace(pattern+"  "+strings.Join(settings, ", ")))
+	}
+	r.addList("Functions", functions)
+	if len(functions) == 0 && len(d.Lambdas) > 0 {
+		r.add("Functions", fmt.Sprintf("%d", len(d.Lambdas)))
+	}
+
+	var crons []string
+	for _, c := range d.Crons {
+		crons = append(crons, c.Schedule+"  "+c.Path)
+	}
+	r.addList("Crons", crons)
+	return r
+}
+
+func aliasRows(d vercel.DeploymentData) rows {
+	var r rows
+	// List every alias, marking the ones serving production traffic
+	var aliases []string
+	for _, a := range d.Alias {
+		if vercel.IsProductionDomain(d, a) {
+			a += " (production)"
+		}
+		aliases = append(aliases, a)
+	}
+	r.addList("Assigned", aliases)
+	r.addList("Automatic", d.AutomaticAliases)
+	return r
+}
+
+// errorRows explains why a deployment failed, or why its aliases couldn't be
+// assigned.
+func errorRows(d vercel.DeploymentData, errorLog []string) rows {
+	var r rows
+	r.add("Step", d.ErrorStep)
+	r.add("Code", d.ErrorCode)
+	r.add("Message", d.ErrorMessage)
+	r.add("Reason", d.ReadyStateReason)
+	r.add("Help", d.ErrorLink)
+	if d.AliasError != nil {
+		r.add("Alias", strings.TrimSpace(d.AliasError.Code+" "+d.AliasError.Message))
+	}
+	if d.ReadyState == string(vercel.ERROR) && len(r) == 0 && len(errorLog) == 0 {
+		r.add("Message", "The build failed without an error message")
+	}
+	r.addList("Build log", errorLog)
+	return r
+}
+
+// DeploymentErrorLog returns the end of the build log of a failed
+// deployment for the detail view, or nothing for deployments that didn't
+// fail.
+func DeploymentErrorLog(v *vercel.VercelAPI, d vercel.DeploymentData) []string {
+	if d.ReadyState != string(vercel.ERROR) {
+		return nil
+	}
+	events, err := v.GetDeploymentEvents(d.ID, dashboardLogLines)
+	if err != nil {
+		return []string{"Couldn't fetch the build log: " + err.Error()}
+	}
+	return failedBuildLog(events)
+}
+
+// failedBuildLog returns the last lines of the error output in a build log, or of
+// the whole log when nothing was written to stderr.
+func failedBuildLog(events []vercel.DeploymentEvent) []string {
+	var failed []vercel.DeploymentEvent
+	for _, e := range events {
+		if e.Type == "stderr" || e.Type == "fatal" {
+			failed = append(failed, e)
+		}
+	}
+	if len(failed) == 0 {
+		failed = events
+	}
+
+	var lines []string
+	for _, e := range failed {
+		for _, line := range strings.Split(strings.TrimRight(e.Message(), "\n"), "\n") {
+			if strings.TrimSpace(line) != "" {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines[max(len(lines)-errorLogLines, 0):]
+}
@@ -0,0 +1,268 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/utils"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+// DIFF_FILE_LINES is how many changed files a comparison lists by default.
+const DIFF_FILE_LINES = 50
+
+// DeploymentDiff is what changed from deployment A to deployment B.
+type DeploymentDiff struct {
+	A, B  vercel.DeploymentData
+	Files []vercel.FileChange
+	// FilesErr is why the file trees couldn't be compared, which leaves the
+	// rest of the comparison intact
+	FilesErr error
+}
+
+// CompareDeployments fetches two deployments by ID, with their file trees,
+// and compares them.
+func CompareDeployments(v *vercel.VercelAPI, idA, idB string) (DeploymentDiff, error) {
+	var diff DeploymentDiff
+	var err error
+	if diff.A, err = v.GetDeployment(idA); err != nil {
+		return diff, fmt.Errorf("fetching %s: %w", idA, err)
+	}
+	if diff.B, err = v.GetDeployment(idB); err != nil {
+		return diff, fmt.Errorf("fetching %s: %w", idB, err)
+	}
+
+	treeA, err := v.GetDeploymentFiles(diff.A.ID)
+	if err != nil {
+		diff.FilesErr = err
+		return diff, nil
+	}
+	treeB, err := v.GetDeploymentFiles(diff.B.ID)
+	if err != nil {
+		diff.FilesErr = err
+		return diff, nil
+	}
+	diff.Files = vercel.DiffFiles(treeA, treeB)
+	return diff, nil
+}
+
+// Sections formats the comparison for display, listing at most fileLines
+// changed files, or all of them when fileLines is 0. Only what differs is
+// shown.
+func (diff DeploymentDiff) Sections(fileLines int) []menu.InfoSection {
+	a, b := diff.A, diff.B
+
+	var overview rows
+	overview.add("A", deploymentSummary(a))
+	overview.add("B", deploymentSummary(b))
+	overview.add("Target", changed(deploymentTarget(a), deploymentTarget(b)))
+	overview.add("State", changed(a.ReadyState, b.ReadyState))
+
+	return []menu.InfoSection{
+		{Title: "Deployments", Rows: overview},
+		{Title: "Git", Rows: gitDiffRows(a.Commit(), b.Commit())},
+		{Title: "Build", Rows: buildDiffRows(a, b)},
+		{Title: "Runtime", Rows: runtimeDiffRows(a, b)},
+		{Title: "Aliases", Rows: aliasDiffRows(a, b)},
+		{Title: "Files", Rows: diff.fileRows(fileLines)},
+	}
+}
+
+func deploymentSummary(d vercel.DeploymentData) string {
+	summary := d.ID
+	if d.URL != "" {
+		summary += "  " + d.URL
+	}
+	if created := d.CreatedTime(); !created.IsZero() {
+		summary += "  " + utils.ElapsedTime(created.Unix())
+	}
+	return summary
+}
+
+// changed returns "a → b" when the values differ, and "" when they don't.
+func changed(a, b string) string {
+	if a == b {
+		return ""
+	}
+	if a == "" {
+		a = "none"
+	}
+	if b == "" {
+		b = "none"
+	}
+	return a + " " + theme.G().Arrow + " " + b
+}
+
+func gitDiffRows(a, b vercel.Commit) rows {
+	var r rows
+	r.add("Repository", changed(a.Repo, b.Repo))
+	r.add("Branch", changed(a.Branch, b.Branch))
+	if a.SHA != b.SHA {
+		if a.SHA != "" && b.SHA != "" {
+			r.add("Commits", shortSHA(a.SHA)+".."+shortSHA(b.SHA))
+		} else {
+			r.add("Commit", changed(shortSHA(a.SHA), shortSHA(b.SHA)))
+		}
+		r.add("Message", changed(a.Subject(), b.Subject()))
+	}
+	r.add("Author", changed(a.Author, b.Author))
+	r.add("Compare", vercel.CompareURL(a, b))
+	return r
+}
+
+func buildDiffRows(a, b vercel.DeploymentData) rows {
+	var r rows
+	sa, sb := a.ProjectSettings, b.ProjectSettings
+	r.add("Framework", changed(sa.Framework, sb.Framework))
+	r.add("Install", changed(sa.InstallCommand, sb.InstallCommand))
+	r.add("Build", changed(sa.BuildCommand, sb.BuildCommand))
+	r.add("Output", changed(sa.OutputDirectory, sb.OutputDirectory))
+	r.add("Root", changed(sa.RootDirectory, sb.RootDirectory))
+	r.add("Node.js", changed(sa.NodeVersion, sb.NodeVersion))
+
+	now := utils.Now()
+	ta, tb := a.Timing(now), b.Timing(now)
+	if ta.Build > 0 && tb.Build > 0 && !ta.Running && !tb.Running {
+		r.add("Duration", changed(utils.FormatDuration(ta.Build), utils.FormatDuration(tb.Build)))
+	}
+	return r
+}
+
+func runtimeDiffRows(a, b vercel.DeploymentData) rows {
+	var r rows
+	r.add("Regions", changed(strings.Join(a.Regions, ", "), strings.Join(b.Regions, ", ")))
+
+	var functions []string
+	all := maps.Clone(a.Functions)
+	if all == nil {
+		all = map[string]vercel.DeploymentFunction{}
+	}
+	maps.Copy(all, b.Functions)
+	for _, p := range slices.Sorted(maps.Keys(all)) {
+		fa, inA := a.Functions[p]
+		fb, inB := b.Functions[p]
+		switch {
+		case !inA:
+			functions = append(functions, "+ "+p+"  "+functionSettings(fb))
+		case !inB:
+			functions = append(functions, "- "+p)
+		case fa != fb:
+			functions = append(functions, "~ "+p+"  "+changed(functionSettings(fa), functionSettings(fb)))
+		}
+	}
+	r.addList("Functions", functions)
+
+	r.addList("Crons", listChanges(cronLines(a), cronLines(b)))
+	return r
+}
+
+func functionSettings(f vercel.DeploymentFunction) string {
+	var settings []string
+	if f.Runtime != "" {
+		settings = append(settings, f.Runtime)
+	}
+	if f.Memory > 0 {
+		settings = append(settings, fmt.Sprintf("%d MB", f.Memory))
+	}
+	if f.MaxDuration > 0 {
+		settings = append(settings, fmt.Sprintf("%ds max", f.MaxDuration))
+	}
+	if len(settings) == 0 {
+		return "defaults"
+	}
+	return strings.Join(settings, ", ")
+}
+
+func cronLines(d vercel.DeploymentData) []string {
+	var crons []string
+	for _, c := range d.Crons {
+		crons = append(crons, c.Schedule+"  "+c.Path)
+	}
+	return crons
+}
+
+func aliasDiffRows(a, b vercel.DeploymentData) rows {
+	var r rows
+	r.addList("Assigned", listChanges(a.Alias, b.Alias))
+	r.addList("Automatic", listChanges(a.AutomaticAliases, b.AutomaticAliases))
+	return r
+}
+
+// listChanges returns the values only in b marked "+", then those only in a
+// marked "-".
+func listChanges(a, b []string) []string {
+	var lines []string
+	for _, v := range b {
+		if !slices.Contains(a, v) {
+			lines = append(lines, "+ "+v)
+		}
+	}
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			lines = append(lines, "- "+v)
+		}
+	}
+	return lines
+}
+
+func (diff DeploymentDiff) fileRows(fileLines int) rows {
+	var r rows
+	if diff.FilesErr != nil {
+		r.add("Error", "Couldn't compare the files: "+diff.FilesErr.Error())
+		return r
+	}
+
+	markers := map[vercel.FileChangeKind]string{vercel.FILE_ADDED: "+ ", vercel.FILE_REMOVED: "- ", vercel.FILE_CHANGED: "~ "}
+	counts := map[vercel.FileChangeKind]int{}
+	var lines []string
+	for _, c := range diff.Files {
+		counts[c.Kind]++
+		lines = append(lines, markers[c.Kind]+c.Path)
+	}
+	if len(lines) == 0 {
+		r.add("Changed", "none")
+		return r
+	}
+	r.add("Changed", fmt.Sprintf("%d added, %d removed, %d changed",
+		counts[vercel.FILE_ADDED], counts[vercel.FILE_REMOVED], counts[vercel.FILE_CHANGED]))
+	if fileLines > 0 && len(lines) > fileLines {
+		more := len(lines) - fileLines
+		lines = append(lines[:fileLines], fmt.Sprintf("%s %d more", theme.G().Ellipsis, more))
+	}
+	r.addList("", lines)
+	return r
+}
+
+// ChooseDeployment asks for another of the latest deployments of d's project,
+// however old, returning its ID, or false if the user went back.
+func ChooseDeployment(v *vercel.VercelAPI, d vercel.DeploymentData, prompt string) (string, bool, error) {
+	if d.ProjectID == "" {
+		return "", false, errors.New("No project ID for " + d.ID)
+	}
+	// One more than shown, as d may be among them
+	dl, err := v.GetProjectDeployments(d.ProjectID, 21)
+	if err != nil {
+		return "", false, err
+	}
+	var others vercel.DeploymentsList
+	for _, o := range dl.Deployments {
+		if o.UID != d.ID {
+			others.Deployments = append(others.Deployments, o)
+		}
+	}
+	others.Deployments = others.Deployments[:min(len(others.Deployments), 20)]
+	if len(others.Deployments) == 0 {
+		return "", false, errors.New("No other deployments of " + d.Name + " to compare with")
+	}
+
+	m := menu.NewMenu(prompt)
+	deploymentTable(m)
+	m.MenuItems = deploymentItems(others)
+	id := m.Display()
+	return id, id != "", Interrupted(m)
+}
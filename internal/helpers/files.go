@@ -0,0 +1,160 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+// FormatFileTree renders a deployment's file tree as indented lines.
+func FormatFileTree(tree []vercel.FileTree) []string {
+	return formatFileTree(sortedFiles(tree), "")
+}
+
+func formatFileTree(tree []vercel.FileTree, indent string) []string {
+	var lines []string
+	for i, f := range tree {
+		branch, childIndent := theme.G().Branch, theme.G().Indent
+		if i == len(tree)-1 {
+			branch, childIndent = theme.G().LastBranch, "    "
+		}
+
+		name := f.Name
+		switch f.Type {
+		case vercel.FILE_DIRECTORY:
+			name += "/"
+		case vercel.FILE_SYMLINK:
+			name += " -> " + f.Symlink
+		case vercel.FILE_LAMBDA, vercel.FILE_MIDDLEWARE:
+			name += " (" + string(f.Type) + ")"
+		}
+
+		lines = append(lines, indent+branch+name)
+		if f.Type == vercel.FILE_DIRECTORY {
+			lines = append(lines, formatFileTree(sortedFiles(f.Children), indent+childIndent)...)
+		}
+	}
+	return lines
+}
+
+// sortedFiles returns a copy of tree with directories first, then by name.
+func sortedFiles(tree []vercel.FileTree) []vercel.FileTree {
+	sorted := append([]vercel.FileTree{}, tree...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if (a.Type == vercel.FILE_DIRECTORY) != (b.Type == vercel.FILE_DIRECTORY) {
+			return a.Type == vercel.FILE_DIRECTORY
+		}
+		return a.Name < b.Name
+	})
+	return sorted
+}
+
+// DownloadDeploymentFiles writes the file or directory at p in the deployment
+// to outDir, keeping its path, and returns the number of files written.
+func DownloadDeploymentFiles(v *vercel.VercelAPI, deploymentId, p, outDir string) (int, error) {
+	tree, err := v.GetDeploymentFiles(deploymentId)
+	if err != nil {
+		return 0, err
+	}
+
+	root, ok := vercel.FindFile(tree, p)
+	if !ok {
+		return 0, errors.New("No file found at " + p)
+	}
+
+	var files = map[string]vercel.FileTree{}
+	if root.Type == vercel.FILE_DIRECTORY {
+		vercel.WalkFiles(root.Children, func(fp string, f vercel.FileTree) {
+			if f.Type == vercel.FILE_FILE {
+				files[path.Join(p, fp)] = f
+			}
+		})
+	} else {
+		files[p] = root
+	}
+
+	written := 0
+	for fp, f := range files {
+		data, err := v.GetDeploymentFileContent(deploymentId, f.UID)
+		if err != nil {
+			return written, fmt.Errorf("downloading %s: %w", fp, err)
+		}
+
+		dest := filepath.Join(outDir, filepath.FromSlash(path.Clean("/"+fp)))
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return written, err
+		}
+		if err := os.WriteFile(dest, data, 0644); err != nil {
+			return written, err
+		}
+		written++
+	}
+
+	return written, nil
+}
+
+// BrowseDeploymentFiles displays a navigable menu of a deployment's file tree.
+// Selecting a directory opens it and selecting a file shows its details.
+func BrowseDeploymentFiles(v *vercel.VercelAPI, deploymentId string) error {
+	tree, err := v.GetDeploymentFiles(deploymentId)
+	if err != nil {
+		return err
+	}
+
+	current := "/"
+	for {
+		dir, ok := vercel.FindFile(tree, current)
+		if !ok {
+			return errors.New("No directory found at " + current)
+		}
+
+		m := menu.NewMenu("Files in " + current)
+		if current != "/" {
+			m.AddItem("..", "../")
+		}
+		children := sortedFiles(dir.Children)
+		for i, f := range children {
+			name := f.Name
+			if f.Type == vercel.FILE_DIRECTORY {
+				name += "/"
+			}
+			m.AddItem(strconv.Itoa(i), name)
+		}
+
+		choice := m.Display()
+		switch choice {
+		case "":
+			return Interrupted(m)
+		case "..":
+			current = path.Dir(current)
+			continue
+		}
+
+		i, err := strconv.Atoi(choice)
+		if err != nil {
+			return err
+		}
+		f := children[i]
+		if f.Type == vercel.FILE_DIRECTORY {
+			current = path.Join(current, f.Name)
+			continue
+		}
+
+		m.DisplayInfoTable([]menu.InfoTableData{
+			{Label: "Path", Data: path.Join(current, f.Name)},
+			{Label: "Type", Data: string(f.Type)},
+			{Label: "Content Type", Data: f.ContentType},
+			{Label: "Mode", Data: fmt.Sprintf("%o", f.Mode)},
+			{Label: "UID", Data: f.UID},
+		})
+	}
+}
@@ -4,13 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/m87wheeler/golang-vercel-cli/internal/environment"
 	"github.com/m87wheeler/golang-vercel-cli/internal/screens"
 	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
-	"github.com/m87wheeler/golang-vercel-cli/pkg/utils"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
 	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
 )
 
@@ -24,10 +28,10 @@ func HandleConfig(e *environment.Environment) (string, string, string, error) {
 	}
 
 	// Load environment variables
-	fmt.Println("Loading env config from " + e.EnvLoadFrom)
+	fmt.Fprintln(os.Stderr, "Loading env config from "+e.EnvLoadFrom)
 	err := godotenv.Load(e.EnvLoadFrom)
 	if err != nil {
-		fmt.Println("Error loading .env file")
+		fmt.Fprintln(os.Stderr, "Error loading .env file")
 		return "", "", "", err
 	}
 
@@ -36,123 +40,301 @@ func HandleConfig(e *environment.Environment) (string, string, string, error) {
 	vercelTeamID := os.Getenv("VERCEL_TEAM_ID")
 
 	if vercelEndpoint == "" || vercelAuthKey == "" || vercelTeamID == "" {
-		fmt.Println("Missing credentials")
-		return "", "", "", err
+		return "", "", "", errors.New("Missing credentials")
 	}
 
 	return vercelEndpoint, vercelAuthKey, vercelTeamID, nil
 }
 
+// DEFAULT_REFRESH_INTERVAL is how often deployment lists are refetched while shown.
+const DEFAULT_REFRESH_INTERVAL = 5 * time.Second
+
+// RefreshInterval is how often deployment lists are refetched, 0 to never
+// refetch them. It is set by ConfigureMenus.
+var RefreshInterval = DEFAULT_REFRESH_INTERVAL
+
+// ConfigureMenus applies user menu settings from the environment. MENU_KEYMAP
+// holds comma separated key=action bindings layered over the default keymap,
+// and REFRESH_INTERVAL how often deployment lists refresh, e.g. "10s".
+// THEME names the colour theme, either built in or defined by a
+// THEME_<NAME> variable of comma separated style=attributes pairs, and
+// GLYPHS=ascii draws with ASCII symbols only. NO_COLOR turns colours off as
+// when set before the CLI started.
+func ConfigureMenus() error {
+	// Colours were detected before the .env file was loaded
+	theme.RedetectColor()
+
+	if interval := os.Getenv("REFRESH_INTERVAL"); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return fmt.Errorf("invalid REFRESH_INTERVAL: %w", err)
+		}
+		RefreshInterval = d
+	}
+
+	if spec := os.Getenv("MENU_KEYMAP"); spec != "" {
+		k, err := menu.ParseKeymap(spec)
+		if err != nil {
+			return fmt.Errorf("MENU_KEYMAP: %w", err)
+		}
+		menu.SetDefaultKeymap(menu.DefaultKeymap().Merge(k))
+	}
+
+	if err := configureTheme(); err != nil {
+		return err
+	}
+	switch glyphs := os.Getenv("GLYPHS"); glyphs {
+	case "", "unicode":
+	case "ascii":
+		theme.SetGlyphs(theme.ASCII_GLYPHS)
+	default:
+		return fmt.Errorf("invalid GLYPHS %q, expected unicode or ascii", glyphs)
+	}
+	return nil
+}
+
+// configureTheme sets the theme named by THEME, collecting the themes defined
+// by THEME_<NAME> variables first. Themes may be based on one another.
+func configureTheme() error {
+	themes := maps.Clone(theme.Themes)
+	specs := map[string]string{}
+	for _, kv := range os.Environ() {
+		key, spec, _ := strings.Cut(kv, "=")
+		if name, ok := strings.CutPrefix(key, "THEME_"); ok {
+			specs[strings.ReplaceAll(strings.ToLower(name), "_", "-")] = spec
+		}
+	}
+	// Parse until every theme's base is known, so definition order doesn't matter
+	for len(specs) > 0 {
+		parsed := false
+		var lastErr error
+		for _, name := range slices.Sorted(maps.Keys(specs)) {
+			t, err := theme.ParseTheme(specs[name], themes)
+			if err != nil {
+				lastErr = fmt.Errorf("THEME_%s: %w", strings.ToUpper(strings.ReplaceAll(name, "-", "_")), err)
+				continue
+			}
+			themes[name] = t
+			delete(specs, name)
+			parsed = true
+		}
+		if !parsed {
+			return lastErr
+		}
+	}
+
+	name := os.Getenv("THEME")
+	if name == "" {
+		return nil
+	}
+	t, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown THEME %q, choose from: %s", name, strings.Join(slices.Sorted(maps.Keys(themes)), ", "))
+	}
+	if err := theme.SetTheme(t); err != nil {
+		return fmt.Errorf("THEME %s: %w", name, err)
+	}
+	return nil
+}
+
+// DefaultScreens returns the screens of the interactive session and the dashboard.
+func DefaultScreens() screens.ScreensList {
+	return screens.ScreensList{
+		Project:     screens.NewScreen(RenderProjectScreen),
+		States:      screens.NewScreen(RenderStatesScreen),
+		Deployments: screens.NewScreen(RenderDeploymentsScreen),
+		Deployment:  screens.NewScreen(RenderDeploymentScreen),
+		Actions:     screens.NewScreen(RenderDeploymentActionsScreen),
+		Perform:     screens.NewScreen(RenderPerformActionScreen),
+		Dashboard:   screens.NewScreen(RenderDashboardScreen),
+	}
+}
+
 // renderProjectScreen displays a menu to select a project and returns the selected project name.
-func RenderProjectScreen(e *environment.Environment) screens.RenderResult {
+func RenderProjectScreen(args screens.RenderArgs) screens.Result[screens.ProjectResult] {
 	m := menu.NewMenu("Select a project")
-	for n := range e.Projects {
+	m.Flag = "--project"
+	// Sort the projects so numbered prompts are stable between runs
+	for _, n := range slices.Sorted(maps.Keys(args.Env.Projects)) {
 		m.AddItem(n, n)
 	}
 	projectName := m.Display()
-	return screens.RenderResult{
-		Data: map[string]any{"projectName": projectName},
+	if projectName == "" {
+		return screens.Result[screens.ProjectResult]{Back: true, Err: Interrupted(m)}
+	}
+	return screens.Result[screens.ProjectResult]{
+		Data: screens.ProjectResult{ProjectName: projectName},
 	}
 }
 
 // renderStatesScreen displays a menu to select deployment statuses and returns the selected states.
-func RenderStatesScreen(e *environment.Environment) screens.RenderResult {
+func RenderStatesScreen(args screens.RenderStatesArgs) screens.Result[screens.StatesResult] {
 	m := menu.NewMenu("Select deployment status'")
+	m.Flag = "--states"
 	for _, s := range vercel.DeploymentStates {
 		ss := string(s)
 		m.AddItem(ss, ss)
 	}
-	states := []string{string(vercel.READY), string(vercel.BUILDING)}
-	m.DisplayMultiChoice(func(choice string) []string {
-		states = utils.ToggleState(states, choice)
-		return states
-	})
-
-	if len(states) < 1 {
-		fmt.Println("Must choose at least 1 state")
-		return screens.RenderResult{Err: errors.New("Must choose at least 1 state")}
+	m.MinSelected = 1
+	if len(args.Selected) > 0 {
+		m.Select(args.Selected...)
+	} else {
+		m.Select(string(vercel.READY), string(vercel.BUILDING))
+	}
+	states, ok := m.DisplayMultiChoice()
+	if !ok {
+		return screens.Result[screens.StatesResult]{Back: true, Err: Interrupted(m)}
 	}
 
-	return screens.RenderResult{
-		Data: map[string]any{"states": states},
+	return screens.Result[screens.StatesResult]{
+		Data: screens.StatesResult{States: states},
 	}
 }
 
 // renderDeploymentsScreen displays a menu to select a deployment and returns the selected deployment ID.
-func RenderDeploymentsScreen(v *vercel.VercelAPI, deployments vercel.DeploymentsList) screens.RenderResult {
+func RenderDeploymentsScreen(args screens.RenderDeploymentsArgs) screens.Result[screens.DeploymentsResult] {
 	m := menu.NewMenu("Select a deployment")
-	for _, d := range deployments.Deployments {
-		elapsed := utils.ElapsedTime(int64(d.Created) / 1000)
-		m.AddItem(d.UID, fmt.Sprintf("%-20s\t%-25s\t%-10s\t%-10s\t%-10s", d.Name, d.Creator.Username, d.Meta.CommitRef, elapsed, d.ReadyState))
+	m.Flag = "--deployment"
+	deploymentTable(m)
+	addScreenActions(m, screens.DEPLOYMENTS, args.VercelAPI)
+	m.MenuItems = deploymentItems(args.DeploymentsList)
+	if args.ProjectName != "" {
+		// Keep the states and ages current while the list is shown
+		m.RefreshInterval = RefreshInterval
+		m.Refresh = func() ([]*menu.MenuItem, error) {
+			dl, err := args.VercelAPI.GetDeployments(args.ProjectName, 10, 24, args.States)
+			return deploymentItems(dl), err
+		}
 	}
 	deploymentId := m.Display()
-	return screens.RenderResult{
-		Data: map[string]any{"deploymentId": deploymentId},
+	if deploymentId == "" {
+		return screens.Result[screens.DeploymentsResult]{Back: true, Err: Interrupted(m)}
+	}
+	return screens.Result[screens.DeploymentsResult]{
+		Data: screens.DeploymentsResult{DeploymentID: deploymentId},
 	}
 }
 
 // renderDeploymentScreen displays detailed information about a deployment and returns the deployment data.
-func RenderDeploymentScreen(v *vercel.VercelAPI, deploymentId string) screens.RenderResult {
+func RenderDeploymentScreen(args screens.RenderDeploymentArgs) screens.Result[screens.DeploymentResult] {
 	m := menu.NewMenu("")
-	d, err := v.GetDeployment(deploymentId)
+	d, err := args.VercelAPI.GetDeployment(args.DeploymentID)
 	if err != nil {
-		return screens.RenderResult{Err: errors.New("No deployment found for " + deploymentId)}
+		return screens.Result[screens.DeploymentResult]{Err: errors.New("No deployment found for " + args.DeploymentID)}
 	}
-	m.DisplayInfoTable(FormatDeploymentTable(d))
-	return screens.RenderResult{
-		Data: map[string]any{"deployment": d},
+	m.DisplayInfoSections(FormatDeploymentDetails(d, DeploymentErrorLog(args.VercelAPI, d)))
+	return screens.Result[screens.DeploymentResult]{
+		Data: screens.DeploymentResult{Deployment: d},
 	}
 }
 
 // renderDeploymentActionsScreen displays a menu to select a deployment action and returns the selected action.
-func RenderDeploymentActionsScreen() screens.RenderResult {
+func RenderDeploymentActionsScreen(args screens.RenderActionsArgs) screens.Result[screens.ActionResult] {
 	m := menu.NewMenu("Deployment Actions")
-	for k, v := range vercel.DeploymentActionsMap {
-		m.AddItem(string(k), v)
+	m.Flag = "--action"
+	for _, a := range args.Actions {
+		m.AddItem(a.ID, a.Label)
 	}
 	action := m.Display()
-	return screens.RenderResult{Data: map[string]any{
-		"action": action,
-	}}
+	if action == "" {
+		return screens.Result[screens.ActionResult]{Back: true, Err: Interrupted(m)}
+	}
+	return screens.Result[screens.ActionResult]{
+		Data: screens.ActionResult{Action: action},
+	}
 }
 
-// formatDeploymentTable formats the deployment data into a slice of InfoTableData.
-func FormatDeploymentTable(d vercel.DeploymentData) []menu.InfoTableData {
-	var url string
-	if len(d.Alias) > 0 {
-		url = d.Alias[0]
+// renderPerformActionScreen performs the selected action on the deployment.
+func RenderPerformActionScreen(args screens.RenderPerformArgs) screens.Result[struct{}] {
+	err := DeploymentAction(args.VercelAPI, args.Action, args.Deployment.ID, args.Deployment)
+	if err == nil {
+		switch args.Action {
+		case string(vercel.CANCEL):
+			fmt.Printf("Cancelled %s\n", args.Deployment.URL)
+		case string(vercel.REDEPLOY):
+			fmt.Printf("Redeploying %s\n", args.Deployment.Name)
+		}
 	}
-	elapsed := utils.ElapsedTime(int64(d.BuildingAt) / 1000)
+	return screens.Result[struct{}]{Err: err}
+}
+
+// ErrNotConfirmed is returned when the user declines to confirm an action.
+var ErrNotConfirmed = errors.New("Not confirmed, nothing was changed")
 
-	return []menu.InfoTableData{
-		{Label: "ID", Data: d.ID},
-		{Label: "Name", Data: d.Name},
-		{Label: "Creator", Data: d.Creator.Username},
-		{Label: "State", Data: vercel.FormatStateString(d.ReadyState)},
-		{Label: "Started", Data: elapsed},
-		{Label: "URL", Data: url},
-		{Label: "Branch", Data: d.GitSource.Branch},
-		{Label: "Commit SHA", Data: d.GitSource.CommitSHA},
+// Interrupted returns menu.ErrInterrupted when m was left with the interrupt
+// key, and nil when the user only went back.
+func Interrupted(m *menu.Menu) error {
+	if errors.Is(m.Err(), menu.ErrInterrupted) {
+		return menu.ErrInterrupted
 	}
+	return nil
+}
+
+// ConfirmDeploymentAction asks the user to confirm verb on a deployment,
+// making them type the deployment name when it targets production. It
+// returns ErrNotConfirmed when they decline.
+func ConfirmDeploymentAction(verb string, d vercel.DeploymentData) error {
+	prompt := fmt.Sprintf("%s deployment %s (%s)?", verb, d.ID, d.Name)
+	expect := ""
+	if d.Target == string(vercel.PRODUCTION) {
+		prompt += " This is a production deployment."
+		expect = d.Name
+	}
+	ok, err := menu.Confirm(prompt, expect)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotConfirmed
+	}
+	return nil
 }
 
 // deploymentAction performs the selected action on the specified deployment.
-func DeploymentAction(v *vercel.VercelAPI, action, deploymentId string, deployment vercel.DeploymentData) {
+func DeploymentAction(v *vercel.VercelAPI, action, deploymentId string, deployment vercel.DeploymentData) error {
 	switch action {
 	case string(vercel.CANCEL):
-		_, err := v.CancelDeployment(deploymentId)
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(0)
+		if err := ConfirmDeploymentAction("Cancel", deployment); err != nil {
+			return err
 		}
+		_, err := v.CancelDeployment(deployment)
+		return err
 	case string(vercel.REDEPLOY):
+		if err := ConfirmDeploymentAction("Redeploy", deployment); err != nil {
+			return err
+		}
 		_, err := v.CreateRedeployment(deployment)
+		return err
+	case string(vercel.FILES):
+		return BrowseDeploymentFiles(v, deploymentId)
+	case string(vercel.OPEN):
+		url, ok, err := ChooseDeploymentURL(deployment, "Open which URL")
+		if err != nil || !ok {
+			return err
+		}
+		return OpenURL(url, os.Stdout)
+	case string(vercel.OPEN_INSPECTOR):
+		url, err := DeploymentURL(deployment, true, 0)
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(0)
+			return err
 		}
-	case string(vercel.EXIT):
-	default:
-		os.Exit(0)
+		return OpenURL(url, os.Stdout)
+	case string(vercel.COPY_URL):
+		url, ok, err := ChooseDeploymentURL(deployment, "Copy which URL")
+		if err != nil || !ok {
+			return err
+		}
+		return CopyURL(url, os.Stdout)
+	case string(vercel.COMPARE):
+		other, ok, err := ChooseDeployment(v, deployment, "Compare with which deployment")
+		if err != nil || !ok {
+			return err
+		}
+		diff, err := CompareDeployments(v, other, deploymentId)
+		if err != nil {
+			return err
+		}
+		menu.NewMenu("").DisplayInfoSections(diff.Sections(DIFF_FILE_LINES))
 	}
+	return nil
 }
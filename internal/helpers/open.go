@@ -0,0 +1,95 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/utils"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+// DeploymentURLs returns the URLs serving a deployment, its own first, then
+// its aliases.
+func DeploymentURLs(d vercel.DeploymentData) []string {
+	var urls []string
+	if d.URL != "" {
+		urls = append(urls, withScheme(d.URL))
+	}
+	for _, a := range d.Alias {
+		urls = append(urls, withScheme(a))
+	}
+	return urls
+}
+
+// DeploymentURL returns the inspector URL of a deployment when inspector is
+// set, its alias n, counted from 1, when n is above 0, and otherwise its own
+// URL.
+func DeploymentURL(d vercel.DeploymentData, inspector bool, alias int) (string, error) {
+	switch {
+	case inspector:
+		if d.InspectorURL == "" {
+			return "", fmt.Errorf("deployment %s has no inspector URL", d.ID)
+		}
+		return d.InspectorURL, nil
+	case alias > 0:
+		if alias > len(d.Alias) {
+			return "", fmt.Errorf("deployment %s has %d aliases, not %d", d.ID, len(d.Alias), alias)
+		}
+		return withScheme(d.Alias[alias-1]), nil
+	case d.URL == "":
+		return "", fmt.Errorf("deployment %s has no URL", d.ID)
+	}
+	return withScheme(d.URL), nil
+}
+
+// ChooseDeploymentURL asks which URL of a deployment to use when it has
+// aliases, returning false if the user went back.
+func ChooseDeploymentURL(d vercel.DeploymentData, prompt string) (string, bool, error) {
+	urls := DeploymentURLs(d)
+	switch len(urls) {
+	case 0:
+		return "", false, nil
+	case 1:
+		return urls[0], true, nil
+	}
+
+	m := menu.NewMenu(prompt)
+	for _, u := range urls {
+		label := u
+		if vercel.IsProductionDomain(d, strings.TrimPrefix(u, "https://")) {
+			label += " (production)"
+		}
+		m.AddItem(u, label)
+	}
+	url := m.Display()
+	return url, url != "", Interrupted(m)
+}
+
+// OpenURL opens url in the browser, printing it as well for when no browser
+// can be started, such as over SSH.
+func OpenURL(url string, out io.Writer) error {
+	if url == "" {
+		return errors.New("No URL to open")
+	}
+	fmt.Fprintln(out, "Opening "+url)
+	return utils.OpenBrowser(url)
+}
+
+// CopyURL copies url to the terminal's clipboard.
+func CopyURL(url string, out io.Writer) error {
+	if err := utils.CopyToClipboard(url); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "Copied "+url)
+	return nil
+}
+
+func withScheme(host string) string {
+	if strings.Contains(host, "://") {
+		return host
+	}
+	return "https://" + host
+}
@@ -0,0 +1,44 @@
+package helpers
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+// DEFAULT_WATCH_INTERVAL is how often a deployment is polled while it builds.
+const DEFAULT_WATCH_INTERVAL = 3 * time.Second
+
+// IsFinalState reports whether a deployment in state will change no further.
+func IsFinalState(state string) bool {
+	switch vercel.DeploymentState(state) {
+	case vercel.READY, vercel.ERROR, vercel.CANCELED:
+		return true
+	default:
+		return false
+	}
+}
+
+// WatchDeployment polls a deployment, printing each state change to out,
+// until it is ready, fails or is cancelled.
+func WatchDeployment(v *vercel.VercelAPI, deploymentId string, interval time.Duration, out io.Writer) (vercel.DeploymentData, error) {
+	lastState := ""
+	for {
+		d, err := v.GetDeployment(deploymentId)
+		if err != nil {
+			return d, err
+		}
+
+		if d.ReadyState != lastState {
+			fmt.Fprintf(out, "%s  %s\n", time.Now().Format(time.TimeOnly), vercel.FormatStateString(d.ReadyState))
+			lastState = d.ReadyState
+		}
+		if IsFinalState(d.ReadyState) {
+			return d, nil
+		}
+
+		time.Sleep(interval)
+	}
+}
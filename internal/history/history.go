@@ -0,0 +1,160 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/utils"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+// HISTORY_FILE_NAME is the audit log's file, kept beside the .env file.
+const HISTORY_FILE_NAME = "history.jsonl"
+
+// Entry is a line of the audit log: a call that changed something on Vercel,
+// with who made it and when.
+type Entry struct {
+	Time time.Time `json:"time"`
+	// Profile is the .env file the CLI was configured from
+	Profile string `json:"profile,omitempty"`
+	User    string `json:"user,omitempty"`
+	vercel.AuditEntry
+	// Undoes is the line of the entry this call reversed, if it was an undo
+	Undoes int `json:"undoes,omitempty"`
+
+	// Line is the entry's line number in the log, counted from 1, which
+	// identifies it to undo
+	Line int `json:"-"`
+}
+
+// OK reports whether the call the entry records succeeded.
+func (e Entry) OK() bool {
+	return e.Result == vercel.AUDIT_OK
+}
+
+// Log is an append-only audit log of JSON lines.
+type Log struct {
+	Path    string
+	Profile string
+	// Undoes, when set, marks the calls recorded as undoing the entry on
+	// that line
+	Undoes int
+}
+
+// NewLog returns the log in dir, recording entries under profile.
+func NewLog(dir, profile string) *Log {
+	return &Log{Path: filepath.Join(dir, HISTORY_FILE_NAME), Profile: profile}
+}
+
+// Record appends a call to the log, for use as VercelAPI.Audit. The call
+// has already been made, so failing to record it is only reported.
+func (l *Log) Record(a vercel.AuditEntry) {
+	e := Entry{Time: utils.Now().UTC(), Profile: l.Profile, User: localUser(), AuditEntry: a, Undoes: l.Undoes}
+	if err := l.Append(e); err != nil {
+		fmt.Fprintln(os.Stderr, "Couldn't record the action in the history: "+err.Error())
+	}
+}
+
+// Append writes e to the end of the log, creating it if needed.
+func (l *Log) Append(e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// Read returns every entry in the log, oldest first. Lines that can't be
+// decoded are skipped, and a log that doesn't exist yet is empty.
+func (l *Log) Read() ([]Entry, error) {
+	f, err := os.Open(l.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		e.Line = n
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Filter selects entries of the log. Empty fields match every entry.
+type Filter struct {
+	Action     string
+	Project    string
+	Deployment string
+	User       string
+	Since      time.Duration // how far back to go, counted from now
+}
+
+// Match reports whether e is selected by the filter.
+func (f Filter) Match(e Entry) bool {
+	switch {
+	case f.Action != "" && e.Action != f.Action:
+		return false
+	case f.Project != "" && e.Project != f.Project:
+		return false
+	case f.Deployment != "" && !slices.Contains(e.Deployments, f.Deployment):
+		return false
+	case f.User != "" && e.User != f.User:
+		return false
+	case f.Since > 0 && e.Time.Before(utils.Now().Add(-f.Since)):
+		return false
+	}
+	return true
+}
+
+// Apply returns the entries selected by the filter.
+func (f Filter) Apply(entries []Entry) []Entry {
+	var matched []Entry
+	for _, e := range entries {
+		if f.Match(e) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// Undone reports whether a later entry of entries undid e.
+func Undone(entries []Entry, e Entry) bool {
+	for _, u := range entries {
+		if u.Undoes == e.Line && u.OK() {
+			return true
+		}
+	}
+	return false
+}
+
+func localUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,151 @@
+package history_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m87wheeler/golang-vercel-cli/internal/history"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/utils"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+var now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// entry returns a successful entry recorded at time t on line.
+func entry(line int, t time.Time, a vercel.AuditEntry) history.Entry {
+	a.Result = vercel.AUDIT_OK
+	return history.Entry{Line: line, Time: t, User: "ana", AuditEntry: a}
+}
+
+func TestFilterMatch(t *testing.T) {
+	utils.SetClock(func() time.Time { return now })
+	t.Cleanup(func() { utils.SetClock(nil) })
+
+	e := entry(1, now.Add(-2*time.Hour), vercel.AuditEntry{
+		Action: vercel.AUDIT_REDEPLOY, Project: "web", Deployments: []string{"dpl_a", "dpl_b"},
+	})
+	tests := []struct {
+		name   string
+		filter history.Filter
+		want   bool
+	}{
+		{name: "empty", filter: history.Filter{}, want: true},
+		{name: "action", filter: history.Filter{Action: vercel.AUDIT_REDEPLOY}, want: true},
+		{name: "other action", filter: history.Filter{Action: vercel.AUDIT_CANCEL}, want: false},
+		{name: "project", filter: history.Filter{Project: "web"}, want: true},
+		{name: "other project", filter: history.Filter{Project: "api"}, want: false},
+		{name: "new deployment", filter: history.Filter{Deployment: "dpl_b"}, want: true},
+		{name: "other deployment", filter: history.Filter{Deployment: "dpl_c"}, want: false},
+		{name: "user", filter: history.Filter{User: "ana"}, want: true},
+		{name: "other user", filter: history.Filter{User: "bo"}, want: false},
+		{name: "since further back", filter: history.Filter{Since: 3 * time.Hour}, want: true},
+		{name: "since exactly then", filter: history.Filter{Since: 2 * time.Hour}, want: true},
+		{name: "since more recently", filter: history.Filter{Since: time.Hour}, want: false},
+		{name: "every field", filter: history.Filter{Action: vercel.AUDIT_REDEPLOY, Project: "web", User: "ana", Since: 24 * time.Hour}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(e); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUndone(t *testing.T) {
+	set := entry(1, now, vercel.AuditEntry{Action: vercel.AUDIT_ALIAS_SET, Alias: "www.example.com", Deployments: []string{"dpl_b", "dpl_a"}})
+	undo := entry(2, now, vercel.AuditEntry{Action: vercel.AUDIT_ALIAS_SET, Alias: "www.example.com", Deployments: []string{"dpl_a", "dpl_b"}})
+	undo.Undoes = 1
+	redo := entry(3, now, vercel.AuditEntry{Action: vercel.AUDIT_ALIAS_SET, Alias: "www.example.com", Deployments: []string{"dpl_b", "dpl_a"}})
+	redo.Undoes = 2
+	failed := entry(4, now, vercel.AuditEntry{Action: vercel.AUDIT_ALIAS_SET, Alias: "www.example.com"})
+	failed.Undoes = 3
+	failed.Result = "not_found"
+	entries := []history.Entry{set, undo, redo, failed}
+
+	tests := []struct {
+		name string
+		e    history.Entry
+		want bool
+	}{
+		{name: "undone change", e: set, want: true},
+		{name: "undone undo", e: undo, want: true},
+		{name: "failed undo doesn't count", e: redo, want: false},
+		{name: "never undone", e: failed, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := history.Undone(entries, tt.e); got != tt.want {
+				t.Errorf("Undone(#%d) = %v, want %v", tt.e.Line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInverse(t *testing.T) {
+	undoOfUndo := entry(2, now, vercel.AuditEntry{Action: vercel.AUDIT_ALIAS_SET, Alias: "www.example.com", Deployments: []string{"dpl_a", "dpl_b"}})
+	undoOfUndo.Undoes = 1
+	failed := entry(1, now, vercel.AuditEntry{Action: vercel.AUDIT_CANCEL, Deployments: []string{"dpl_a"}})
+	failed.Result = "deployment_not_building"
+
+	tests := []struct {
+		name string
+		e    history.Entry
+		want string // the undo's description, "" when there is none
+	}{
+		{name: "new alias", e: entry(1, now, vercel.AuditEntry{Action: vercel.AUDIT_ALIAS_SET, Alias: "www.example.com", Deployments: []string{"dpl_a"}}), want: "Remove the alias www.example.com"},
+		{name: "moved alias", e: entry(1, now, vercel.AuditEntry{Action: vercel.AUDIT_ALIAS_SET, Alias: "www.example.com", Deployments: []string{"dpl_b", "dpl_a"}}), want: "Point www.example.com back at dpl_a"},
+		{name: "undo of an undo", e: undoOfUndo, want: "Point www.example.com back at dpl_b"},
+		{name: "removed alias", e: entry(1, now, vercel.AuditEntry{Action: vercel.AUDIT_ALIAS_REMOVE, Alias: "www.example.com"})},
+		{name: "cancel", e: entry(1, now, vercel.AuditEntry{Action: vercel.AUDIT_CANCEL, Deployments: []string{"dpl_a"}}), want: "Redeploy dpl_a, which was cancelled"},
+		{name: "redeploy", e: entry(1, now, vercel.AuditEntry{Action: vercel.AUDIT_REDEPLOY, Deployments: []string{"dpl_a", "dpl_b"}}), want: "Cancel dpl_b if it is still building"},
+		{name: "redeploy that created nothing", e: entry(1, now, vercel.AuditEntry{Action: vercel.AUDIT_REDEPLOY, Deployments: []string{"dpl_a"}})},
+		{name: "deploy", e: entry(1, now, vercel.AuditEntry{Action: vercel.AUDIT_DEPLOY, Deployments: []string{"dpl_c"}}), want: "Cancel dpl_c if it is still building"},
+		{name: "added DNS record", e: entry(1, now, vercel.AuditEntry{Action: vercel.AUDIT_DNS_ADD, Domain: "example.com", Record: "rec_1"}), want: "Remove the DNS record rec_1 from example.com"},
+		{name: "removed DNS record", e: entry(1, now, vercel.AuditEntry{Action: vercel.AUDIT_DNS_REMOVE, Domain: "example.com", Record: "rec_1"})},
+		{name: "failed call", e: failed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			undo, ok := history.Inverse(tt.e)
+			if ok != (tt.want != "") || undo.Description != tt.want {
+				t.Errorf("Inverse() = %q, %v, want %q", undo.Description, ok, tt.want)
+			}
+			if ok && undo.Run == nil {
+				t.Error("Inverse() returned an undo without Run")
+			}
+		})
+	}
+}
+
+func TestLogReadNumbersLines(t *testing.T) {
+	utils.SetClock(func() time.Time { return now })
+	t.Cleanup(func() { utils.SetClock(nil) })
+
+	l := history.NewLog(t.TempDir(), ".env")
+	l.Record(vercel.AuditEntry{Action: vercel.AUDIT_DOMAIN_ADD, Domain: "example.com", Result: vercel.AUDIT_OK})
+	l.Undoes = 1
+	l.Record(vercel.AuditEntry{Action: vercel.AUDIT_DOMAIN_REMOVE, Domain: "example.com", Result: vercel.AUDIT_OK})
+
+	entries, err := l.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Read() returned %d entries, want 2", len(entries))
+	}
+	for i, e := range entries {
+		if e.Line != i+1 || !e.Time.Equal(now) || e.Profile != ".env" {
+			t.Errorf("entry %d = line %d at %v from %q, want line %d at %v from .env", i, e.Line, e.Time, e.Profile, i+1, now)
+		}
+	}
+	if entries[1].Undoes != 1 || !history.Undone(entries, entries[0]) {
+		t.Errorf("the second entry doesn't undo the first: %+v", entries[1])
+	}
+
+	missing, err := history.NewLog(filepath.Join(t.TempDir(), "none"), "").Read()
+	if err != nil || missing != nil {
+		t.Errorf("Read() of a missing log = %v, %v, want nil, nil", missing, err)
+	}
+}
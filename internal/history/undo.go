@@ -0,0 +1,102 @@
+package history
+
+import (
+	"fmt"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+// Undo is the action reversing an entry of the log.
+type Undo struct {
+	Description string
+	Run         func(v *vercel.VercelAPI) error
+}
+
+// Inverse returns the action reversing e, or false when it failed or can't
+// be reversed, such as removing an alias or DNS record, whose targets
+// weren't kept.
+func Inverse(e Entry) (Undo, bool) {
+	if !e.OK() {
+		return Undo{}, false
+	}
+
+	switch e.Action {
+	case vercel.AUDIT_CANCEL:
+		// A cancelled build can't be resumed, only built again
+		if len(e.Deployments) == 0 {
+			break
+		}
+		id := e.Deployments[0]
+		return Undo{
+			Description: "Redeploy " + id + ", which was cancelled",
+			Run: func(v *vercel.VercelAPI) error {
+				d, err := v.GetDeployment(id)
+				if err != nil {
+					return err
+				}
+				_, err = v.CreateRedeployment(d)
+				return err
+			},
+		}, true
+	case vercel.AUDIT_REDEPLOY, vercel.AUDIT_DEPLOY:
+		// The new deployment is listed last
+		if len(e.Deployments) == 0 || e.Action == vercel.AUDIT_REDEPLOY && len(e.Deployments) < 2 {
+			break
+		}
+		// Vercel only cancels builds, a deployment that finished stays
+		id := e.Deployments[len(e.Deployments)-1]
+		return Undo{
+			Description: "Cancel " + id + " if it is still building",
+			Run: func(v *vercel.VercelAPI) error {
+				d, err := v.GetDeployment(id)
+				if err != nil {
+					return err
+				}
+				switch vercel.DeploymentState(d.ReadyState) {
+				case vercel.READY, vercel.ERROR, vercel.CANCELED:
+					return fmt.Errorf("Deployment %s is %s, only a deployment that is still building can be cancelled", id, d.ReadyState)
+				}
+				_, err = v.CancelDeployment(d)
+				return err
+			},
+		}, true
+	case vercel.AUDIT_ALIAS_SET:
+		if len(e.Deployments) < 2 {
+			// The alias was new
+			return Undo{
+				Description: "Remove the alias " + e.Alias,
+				Run:         func(v *vercel.VercelAPI) error { return v.RemoveAlias(e.Alias) },
+			}, true
+		}
+		old := e.Deployments[1]
+		return Undo{
+			Description: fmt.Sprintf("Point %s back at %s", e.Alias, old),
+			Run: func(v *vercel.VercelAPI) error {
+				_, err := v.AssignAlias(old, e.Alias)
+				return err
+			},
+		}, true
+	case vercel.AUDIT_DOMAIN_ADD:
+		return Undo{
+			Description: "Remove the domain " + e.Domain,
+			Run:         func(v *vercel.VercelAPI) error { return v.RemoveDomain(e.Domain) },
+		}, true
+	case vercel.AUDIT_DOMAIN_REMOVE:
+		return Undo{
+			Description: "Add the domain " + e.Domain + " again, without the aliases and DNS records removed with it",
+			Run: func(v *vercel.VercelAPI) error {
+				_, err := v.AddDomain(e.Domain)
+				return err
+			},
+		}, true
+	case vercel.AUDIT_DNS_ADD:
+		if e.Record == "" {
+			break
+		}
+		return Undo{
+			Description: fmt.Sprintf("Remove the DNS record %s from %s", e.Record, e.Domain),
+			Run:         func(v *vercel.VercelAPI) error { return v.RemoveDNSRecord(e.Domain, e.Record) },
+		}, true
+	}
+	return Undo{}, false
+}
@@ -0,0 +1,177 @@
+package screens
+
+import (
+	"errors"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+type navKind int
+
+const (
+	navPush navKind = iota
+	navReplace
+	navBack
+	navBackTo
+	navQuit
+)
+
+// Nav tells the router where to go after a screen has rendered.
+type Nav struct {
+	kind   navKind
+	screen string
+}
+
+// Push navigates to screen, keeping the current screen in the history.
+func Push(screen string) Nav { return Nav{kind: navPush, screen: screen} }
+
+// Replace navigates to screen, dropping the current screen from the history.
+func Replace(screen string) Nav { return Nav{kind: navReplace, screen: screen} }
+
+// Back returns to the previous screen.
+func Back() Nav { return Nav{kind: navBack} }
+
+// BackTo returns to the most recent visit of screen, dropping everything after it.
+func BackTo(screen string) Nav { return Nav{kind: navBackTo, screen: screen} }
+
+// Quit ends the session.
+func Quit() Nav { return Nav{kind: navQuit} }
+
+// Route is a screen bound into navigation. Create one with Bind.
+type Route struct {
+	Name  string
+	Label string // when set, the screen is offered as a deployment action
+	run   func(s *Screens) (Nav, error)
+}
+
+// Bind creates a route for screen. in builds the screen's input from the
+// session and out stores its output and decides where to navigate next.
+// Going back from the screen is handled by the router.
+func Bind[In, Out any](name string, screen Screen[In, Out], in func(s *Session) (In, error), out func(s *Session, data Out) Nav) Route {
+	return Route{
+		Name: name,
+		run: func(s *Screens) (Nav, error) {
+			args, err := in(s.Session)
+			if err != nil {
+				return Nav{}, err
+			}
+			r := screen.Render(args)
+			if r.Err != nil {
+				return Nav{}, r.Err
+			}
+			if r.Back {
+				return Back(), nil
+			}
+			return out(s.Session, r.Data), nil
+		},
+	}
+}
+
+// AsAction offers the route as a deployment action with the given label.
+func (r Route) AsAction(label string) Route {
+	r.Label = label
+	return r
+}
+
+var registry []Route
+
+// Register adds a custom screen to every session created afterwards, letting
+// packages add screens, for example from an init function, without changing
+// the default flow.
+func Register(r Route) {
+	registry = append(registry, r)
+}
+
+// defaultRoutes binds the screens of list into the default flow of
+// project -> states -> deployments -> deployment -> actions, and the
+// dashboard, which replaces the first three screens.
+func (scr *Screens) defaultRoutes(list ScreensList) []Route {
+	return []Route{
+		Bind(PROJECT, list.Project,
+			func(s *Session) (RenderArgs, error) {
+				return RenderArgs{Env: s.Env}, nil
+			},
+			func(s *Session, r ProjectResult) Nav {
+				s.ProjectName = r.ProjectName
+				return Push(STATES)
+			},
+		),
+		Bind(STATES, list.States,
+			func(s *Session) (RenderStatesArgs, error) {
+				return RenderStatesArgs{Env: s.Env, Selected: s.States}, nil
+			},
+			func(s *Session, r StatesResult) Nav {
+				s.States = r.States
+				return Push(DEPLOYMENTS)
+			},
+		),
+		Bind(DEPLOYMENTS, list.Deployments,
+			func(s *Session) (RenderDeploymentsArgs, error) {
+				// Fetch deployments every time the list is shown so it is never stale
+				dl, err := s.API.GetDeployments(s.ProjectName, 10, 24, s.States)
+				if err != nil {
+					return RenderDeploymentsArgs{}, err
+				} else if len(dl.Deployments) < 1 {
+					return RenderDeploymentsArgs{}, errors.New("No deployments to display")
+				}
+				return RenderDeploymentsArgs{VercelAPI: s.API, DeploymentsList: dl, ProjectName: s.ProjectName, States: s.States}, nil
+			},
+			func(s *Session, r DeploymentsResult) Nav {
+				s.DeploymentID = r.DeploymentID
+				s.ListScreen = DEPLOYMENTS
+				return Push(DEPLOYMENT)
+			},
+		),
+		Bind(DEPLOYMENT, list.Deployment,
+			func(s *Session) (RenderDeploymentArgs, error) {
+				return RenderDeploymentArgs{VercelAPI: s.API, DeploymentID: s.DeploymentID}, nil
+			},
+			func(s *Session, r DeploymentResult) Nav {
+				s.Deployment = r.Deployment
+				// The details stay printed above the actions, going back skips them
+				return Replace(ACTIONS)
+			},
+		),
+		Bind(ACTIONS, list.Actions,
+			func(s *Session) (RenderActionsArgs, error) {
+				return RenderActionsArgs{Deployment: s.Deployment, Actions: scr.Actions()}, nil
+			},
+			func(s *Session, r ActionResult) Nav {
+				s.Action = r.Action
+				switch r.Action {
+				case string(vercel.EXIT):
+					return Quit()
+				case string(vercel.CANCEL), string(vercel.REDEPLOY), string(vercel.FILES),
+					string(vercel.OPEN), string(vercel.OPEN_INSPECTOR), string(vercel.COPY_URL), string(vercel.COMPARE):
+					return Push(PERFORM)
+				default:
+					// Actions provided by registered screens navigate to them
+					return Push(r.Action)
+				}
+			},
+		),
+		Bind(PERFORM, list.Perform,
+			func(s *Session) (RenderPerformArgs, error) {
+				return RenderPerformArgs{VercelAPI: s.API, Action: s.Action, Deployment: s.Deployment}, nil
+			},
+			func(s *Session, _ struct{}) Nav {
+				if s.NoInteractive {
+					return Quit()
+				}
+				// Return to the refreshed list the deployment was chosen from
+				return BackTo(s.ListScreen)
+			},
+		),
+		Bind(DASHBOARD, list.Dashboard,
+			func(s *Session) (RenderDashboardArgs, error) {
+				return RenderDashboardArgs{Env: s.Env, VercelAPI: s.API, ProjectName: s.ProjectName, States: s.States}, nil
+			},
+			func(s *Session, r DashboardResult) Nav {
+				s.ProjectName = r.ProjectName
+				s.DeploymentID = r.DeploymentID
+				s.ListScreen = DASHBOARD
+				return Push(DEPLOYMENT)
+			},
+		),
+	}
+}
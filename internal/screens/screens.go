@@ -1,22 +1,50 @@
 package screens
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
 	"github.com/m87wheeler/golang-vercel-cli/internal/environment"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
 	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
 )
 
-type RenderResult struct {
-	Data map[string]any
+// Screen names used for navigation
+const (
+	PROJECT     = "project"
+	STATES      = "states"
+	DEPLOYMENTS = "deployments"
+	DEPLOYMENT  = "deployment"
+	ACTIONS     = "actions"
+	PERFORM     = "perform"
+	DASHBOARD   = "dashboard"
+)
+
+// Result is returned by every screen, carrying its typed output.
+type Result[Out any] struct {
+	Data Out
 	Err  error
+	Back bool // the user asked to return to the previous screen
 }
 
 type RenderArgs struct {
 	Env *environment.Environment
 }
 
+type RenderStatesArgs struct {
+	Env      *environment.Environment
+	Selected []string
+}
+
 type RenderDeploymentsArgs struct {
 	VercelAPI       *vercel.VercelAPI
 	DeploymentsList vercel.DeploymentsList
+	// ProjectName and States are what DeploymentsList was fetched for, to
+	// refetch it while the list is shown
+	ProjectName string
+	States      []string
 }
 
 type RenderDeploymentArgs struct {
@@ -24,26 +52,207 @@ type RenderDeploymentArgs struct {
 	DeploymentID string
 }
 
-type Screen[T any] struct {
-	Render func(args T) RenderResult
+type RenderActionsArgs struct {
+	Deployment vercel.DeploymentData
+	Actions    []ActionItem
+}
+
+type RenderPerformArgs struct {
+	VercelAPI  *vercel.VercelAPI
+	Action     string
+	Deployment vercel.DeploymentData
+}
+
+type RenderDashboardArgs struct {
+	Env         *environment.Environment
+	VercelAPI   *vercel.VercelAPI
+	ProjectName string
+	States      []string
+}
+
+// ActionItem is a deployment action offered on the actions screen.
+type ActionItem struct {
+	ID    string
+	Label string
+}
+
+type ProjectResult struct {
+	ProjectName string
+}
+
+type StatesResult struct {
+	States []string
+}
+
+type DeploymentsResult struct {
+	DeploymentID string
+}
+
+type DeploymentResult struct {
+	Deployment vercel.DeploymentData
+}
+
+type ActionResult struct {
+	Action string
+}
+
+type DashboardResult struct {
+	ProjectName  string
+	DeploymentID string
+}
+
+// Screen renders from a typed input to a typed output.
+type Screen[In, Out any] struct {
+	Render func(args In) Result[Out]
 }
 
+// NewScreen creates a screen from a render function, inferring its types.
+func NewScreen[In, Out any](render func(args In) Result[Out]) Screen[In, Out] {
+	return Screen[In, Out]{Render: render}
+}
+
+// ScreensList holds the screens making up the default navigation flow.
 type ScreensList struct {
-	Project     func(e *environment.Environment) RenderResult
-	States      func(e *environment.Environment) RenderResult
-	Deployments func(v *vercel.VercelAPI, d vercel.DeploymentsList) RenderResult
-	Deployment  func(v *vercel.VercelAPI, id string) RenderResult
-	Actions     func() RenderResult
+	Project     Screen[RenderArgs, ProjectResult]
+	States      Screen[RenderStatesArgs, StatesResult]
+	Deployments Screen[RenderDeploymentsArgs, DeploymentsResult]
+	Deployment  Screen[RenderDeploymentArgs, DeploymentResult]
+	Actions     Screen[RenderActionsArgs, ActionResult]
+	Perform     Screen[RenderPerformArgs, struct{}]
+	Dashboard   Screen[RenderDashboardArgs, DashboardResult]
+}
+
+// Session holds the selections made so far, shared by every screen.
+type Session struct {
+	Env          *environment.Environment
+	API          *vercel.VercelAPI
+	ProjectName  string
+	States       []string
+	DeploymentID string
+	Deployment   vercel.DeploymentData
+	Action       string
+	// ListScreen is the screen the deployment was chosen from, returned to
+	// once an action has been performed
+	ListScreen string
+	// NoInteractive quits after an action is performed instead of returning
+	// to the deployments list, which would need input again
+	NoInteractive bool
 }
 
 type Screens struct {
 	List          ScreensList
+	Session       *Session
 	CurrentScreen string
+	routes        map[string]Route
+	actions       []ActionItem
+	history       []string
 }
 
-func NewScreens(screens ScreensList, initialScreen string) *Screens {
-	return &Screens{
+func NewScreens(screens ScreensList, session *Session, initialScreen string) *Screens {
+	s := &Screens{
 		List:          screens,
+		Session:       session,
 		CurrentScreen: initialScreen,
+		routes:        map[string]Route{},
+	}
+	for _, k := range slices.Sorted(maps.Keys(vercel.DeploymentActionsMap)) {
+		s.actions = append(s.actions, ActionItem{ID: string(k), Label: vercel.DeploymentActionsMap[k]})
+	}
+	for _, r := range s.defaultRoutes(screens) {
+		s.Register(r)
+	}
+	for _, r := range registry {
+		s.Register(r)
+	}
+	return s
+}
+
+// Register adds a route to the navigation. Routes with a Label are also
+// offered as actions on the deployment actions screen.
+func (s *Screens) Register(r Route) {
+	s.routes[r.Name] = r
+	if r.Label != "" {
+		s.actions = append(s.actions, ActionItem{ID: r.Name, Label: r.Label})
+	}
+}
+
+// Actions returns the built in deployment actions followed by those provided
+// by registered screens.
+func (s *Screens) Actions() []ActionItem {
+	return s.actions
+}
+
+// Run renders screens, following the navigation each one returns, until the
+// user quits or goes back from the first screen. Screen errors are shown and
+// return the user to the previous screen, except menu.ErrInterrupted, which
+// ends the session and is returned.
+func (s *Screens) Run() error {
+	for {
+		r, ok := s.routes[s.CurrentScreen]
+		if !ok {
+			return fmt.Errorf("unknown screen %q", s.CurrentScreen)
+		}
+
+		nav, err := r.run(s)
+		if errors.Is(err, menu.ErrInterrupted) {
+			return err
+		}
+		if err != nil {
+			fmt.Println(err)
+			nav = Back()
+		}
+
+		switch nav.kind {
+		case navPush:
+			s.Push(nav.screen)
+		case navReplace:
+			s.Replace(nav.screen)
+		case navBack:
+			if !s.Back() {
+				return nil
+			}
+		case navBackTo:
+			if !s.BackTo(nav.screen) {
+				return nil
+			}
+		case navQuit:
+			return nil
+		}
+	}
+}
+
+// Push navigates to screen, remembering the current screen for Back.
+func (s *Screens) Push(screen string) {
+	s.history = append(s.history, s.CurrentScreen)
+	s.CurrentScreen = screen
+}
+
+// Replace navigates to screen without remembering the current screen, so
+// Back skips over it. Used for screens that only display information.
+func (s *Screens) Replace(screen string) {
+	s.CurrentScreen = screen
+}
+
+// Back returns to the previous screen. It returns false when there is no
+// previous screen to return to.
+func (s *Screens) Back() bool {
+	if len(s.history) == 0 {
+		return false
+	}
+	s.CurrentScreen = s.history[len(s.history)-1]
+	s.history = s.history[:len(s.history)-1]
+	return true
+}
+
+// BackTo returns to the most recent visit of screen. It returns false when
+// screen isn't in the history.
+func (s *Screens) BackTo(screen string) bool {
+	for i := len(s.history) - 1; i >= 0; i-- {
+		if s.history[i] == screen {
+			s.CurrentScreen = screen
+			s.history = s.history[:i]
+			return true
+		}
 	}
+	return false
 }
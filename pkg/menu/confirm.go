@@ -0,0 +1,120 @@
+package menu
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
+)
+
+var assumeYes bool
+
+// SetAssumeYes makes every confirmation pass without asking, as when --yes is given.
+func SetAssumeYes(yes bool) {
+	assumeYes = yes
+}
+
+// Confirm asks the user to confirm prompt, defaulting to no, and returns
+// whether they agreed. When expect is set the user must type it exactly
+// instead of answering y, for actions that are hard to undo. Input that
+// can't be read answers no. The key bound to back in the default keymap
+// answers no, and the key bound to interrupt returns ErrInterrupted.
+func Confirm(prompt, expect string) (bool, error) {
+	return confirm(defaultTerminal, defaultKeymap, prompt, expect)
+}
+
+func confirm(t Terminal, k Keymap, prompt, expect string) (bool, error) {
+	if assumeYes {
+		fmt.Fprintf(t, "%s yes (--yes)\n", prompt)
+		return true, nil
+	}
+
+	switch terminalMode(t) {
+	case MODE_NONE:
+		log.Fatalf("%q needs confirming, which isn't allowed with --no-interactive; pass --yes instead", prompt)
+	case MODE_LINES:
+		return confirmLine(t, prompt, expect), nil
+	}
+
+	restore, err := t.MakeRaw()
+	if err != nil {
+		fmt.Fprintf(t, "%s %s\n", prompt, err)
+		return false, nil
+	}
+	defer restore()
+	in := &keyReader{t: t}
+
+	// Raw mode doesn't translate newlines, so every line ends with "\r\n"
+	if expect == "" {
+		fmt.Fprintf(t, "%s %s ", theme.Paint(theme.STYLE_PROMPT, prompt), "[y/N]")
+	} else {
+		fmt.Fprintf(t, "%s\r\nType %s to confirm: ", theme.Paint(theme.STYLE_PROMPT, prompt), theme.Paint(theme.STYLE_CURSOR, expect))
+	}
+
+	var typed []rune
+	for {
+		key, err := in.ReadKey()
+		if err != nil {
+			fmt.Fprint(t, "\r\n")
+			return false, nil
+		}
+
+		action := k[key.Name]
+		if expect != "" && key.Rune != 0 {
+			// Characters are typed, as when filtering a menu
+			action = ""
+		}
+
+		switch {
+		case action == ACTION_INTERRUPT:
+			fmt.Fprint(t, "\r\n")
+			return false, ErrInterrupted
+		case action == ACTION_BACK:
+			fmt.Fprint(t, "\r\n")
+			return false, nil
+		case expect == "":
+			// Any key other than y answers no
+			if key.Name == KEY_RESIZE {
+				continue
+			}
+			yes := key.Rune == 'y' || key.Rune == 'Y'
+			if yes {
+				fmt.Fprint(t, "y\r\n")
+			} else {
+				fmt.Fprint(t, "n\r\n")
+			}
+			return yes, nil
+		case key.Name == KEY_ENTER:
+			fmt.Fprint(t, "\r\n")
+			return string(typed) == expect, nil
+		case key.Name == KEY_BACKSPACE:
+			if len(typed) > 0 {
+				typed = typed[:len(typed)-1]
+				fmt.Fprint(t, "\b \b")
+			}
+		case key.Rune != 0:
+			typed = append(typed, key.Rune)
+			fmt.Fprint(t, string(key.Rune))
+		}
+	}
+}
+
+// confirmLine asks for confirmation with a line of input, when stdin isn't a terminal.
+func confirmLine(t Terminal, prompt, expect string) bool {
+	if expect == "" {
+		fmt.Fprintf(t, "%s [y/N] ", prompt)
+	} else {
+		fmt.Fprintf(t, "%s\nType %s to confirm: ", prompt, expect)
+	}
+
+	line, err := lineInput.ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Fprintln(t)
+	}
+	line = strings.TrimSpace(line)
+	if expect != "" {
+		return line == expect
+	}
+	return strings.EqualFold(line, "y") || strings.EqualFold(line, "yes")
+}
@@ -0,0 +1,135 @@
+package menu
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
+)
+
+// fuzzyMatch reports whether every rune of pattern appears in text, in order
+// and ignoring case, returning the rune positions in text that matched.
+func fuzzyMatch(pattern, text string) ([]int, bool) {
+	if pattern == "" {
+		return nil, true
+	}
+
+	p := []rune(strings.ToLower(pattern))
+	var positions []int
+	i := 0
+	for pos, r := range []rune(text) {
+		if unicode.ToLower(r) == p[i] {
+			positions = append(positions, pos)
+			i++
+			if i == len(p) {
+				return positions, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// applyFilter recalculates which menu items match the current filter and
+// keeps the cursor on the same item when it is still visible.
+func (m *Menu) applyFilter() {
+	m.visible = m.visible[:0]
+	m.matches = map[int][]int{}
+	for i, item := range m.MenuItems {
+		if positions, ok := fuzzyMatch(m.filter, item.Text); ok {
+			m.visible = append(m.visible, i)
+			m.matches[i] = positions
+		}
+	}
+
+	for _, i := range m.visible {
+		if i == m.CursorPos {
+			return
+		}
+	}
+	if len(m.visible) > 0 {
+		m.CursorPos = m.visible[0]
+	}
+}
+
+// moveCursor moves the cursor by delta rows through the visible items, wrapping around.
+func (m *Menu) moveCursor(delta int) {
+	if len(m.visible) == 0 {
+		return
+	}
+	row := 0
+	for r, i := range m.visible {
+		if i == m.CursorPos {
+			row = r
+			break
+		}
+	}
+	row = (row + delta%len(m.visible) + len(m.visible)) % len(m.visible)
+	m.CursorPos = m.visible[row]
+}
+
+// cursorVisible reports whether the item under the cursor matches the filter.
+func (m *Menu) cursorVisible() bool {
+	_, ok := m.matches[m.CursorPos]
+	return ok && len(m.visible) > 0
+}
+
+// handleFilterKey updates the filter for a key pressed in filter mode.
+// It returns false when the key isn't used by the filter.
+func (m *Menu) handleFilterKey(key Key) bool {
+	switch {
+	case key.Name == KEY_BACKSPACE:
+		if m.filter == "" {
+			m.filtering = false
+		} else {
+			f := []rune(m.filter)
+			m.filter = string(f[:len(f)-1])
+		}
+	case key.Name == KEY_ESCAPE:
+		m.filter = ""
+		m.filtering = false
+	case key.Rune != 0 && key.Rune != ' ':
+		m.filter += string(key.Rune)
+	default:
+		return false
+	}
+	m.applyFilter()
+	return true
+}
+
+// highlight paints the runes of text at positions in the match style and the
+// remaining runes in baseStyle, when it is set.
+func highlight(text string, positions []int, baseStyle string) string {
+	if len(positions) == 0 {
+		return theme.Paint(baseStyle, text)
+	}
+
+	matched := map[int]bool{}
+	for _, p := range positions {
+		matched[p] = true
+	}
+
+	var b strings.Builder
+	var run []rune
+	runMatched := false
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		if runMatched {
+			b.WriteString(theme.Paint(theme.STYLE_MATCH, string(run)))
+		} else {
+			b.WriteString(theme.Paint(baseStyle, string(run)))
+		}
+		run = run[:0]
+	}
+	for i, r := range []rune(text) {
+		if matched[i] != runMatched {
+			flush()
+			runMatched = matched[i]
+		}
+		run = append(run, r)
+	}
+	flush()
+
+	return b.String()
+}
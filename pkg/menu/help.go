@@ -0,0 +1,214 @@
+package menu
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
+)
+
+// MenuAction is an extra action a screen adds to a menu or layout, run by a
+// single key and listed in the key hints and help.
+type MenuAction struct {
+	Key         string // the key name, as in a Keymap
+	Label       string // a word or two for the key hints footer
+	Description string // a sentence for the help overlay
+	// Run acts on the item under the cursor, returning a message shown below
+	// the menu until the next key. Actions handled elsewhere, such as by a
+	// layout's Update, leave it nil and are only listed.
+	Run func(item *MenuItem) string
+	// Suspend clears the menu and restores the terminal while Run asks for
+	// confirmation or prints output, drawing the menu again below it after.
+	// Suspending actions aren't available in a Layout.
+	Suspend bool
+}
+
+// AddAction adds an extra action to the menu. Its key takes precedence over
+// the keymap and over typing to filter while the filter is empty and not
+// being edited, so filtering by it needs the filter key first.
+func (m *Menu) AddAction(a MenuAction) *Menu {
+	m.Actions = append(m.Actions, a)
+	return m
+}
+
+// quickKeys reports whether any of extra is run by a character that would
+// otherwise start filtering.
+func quickKeys(extra []MenuAction) bool {
+	return slices.ContainsFunc(extra, func(a MenuAction) bool { return len([]rune(a.Key)) == 1 })
+}
+
+// extraAction returns the extra action bound to key, if any.
+func (m *Menu) extraAction(key string) (MenuAction, bool) {
+	for _, a := range m.Actions {
+		if a.Key == key {
+			return a, true
+		}
+	}
+	return MenuAction{}, false
+}
+
+// actionHelp labels the keymap actions in the key hints footer and describes
+// them in the help overlay.
+var actionHelp = map[Action]struct{ label, description string }{
+	ACTION_UP:          {"up", "Move up"},
+	ACTION_DOWN:        {"down", "Move down"},
+	ACTION_PAGE_UP:     {"page up", "Move up a page"},
+	ACTION_PAGE_DOWN:   {"page down", "Move down a page"},
+	ACTION_HOME:        {"first", "Move to the first item"},
+	ACTION_END:         {"last", "Move to the last item"},
+	ACTION_SELECT:      {"select", "Choose the item under the cursor"},
+	ACTION_TOGGLE:      {"toggle", "Check or uncheck the item under the cursor"},
+	ACTION_FILTER:      {"filter", "Filter the items by typing, or just start typing"},
+	ACTION_BACK:        {"back", "Go back, or stop filtering"},
+	ACTION_INTERRUPT:   {"quit", "Quit"},
+	ACTION_SELECT_ALL:  {"all", "Check every item matching the filter"},
+	ACTION_SELECT_NONE: {"none", "Uncheck every item matching the filter"},
+	ACTION_INVERT:      {"invert", "Invert the checked items matching the filter"},
+	ACTION_PAUSE:       {"pause", "Pause or resume refreshing"},
+	ACTION_SORT:        {"sort", "Sort by a column, chosen by its number; again to reverse the order"},
+	ACTION_HELP:        {"help", "Show or hide this help"},
+}
+
+// hintActions are the actions listed in the key hints footer, after moving
+var hintActions = []Action{ACTION_SELECT, ACTION_TOGGLE, ACTION_SELECT_ALL, ACTION_FILTER, ACTION_BACK}
+
+// keyOrder lists the named keys shown first when several keys are bound to
+// an action, so hints show the arrow keys and Enter rather than vim keys.
+var keyOrder = []string{
+	KEY_UP, KEY_DOWN, KEY_PAGE_UP, KEY_PAGE_DOWN, KEY_HOME, KEY_END,
+	KEY_ENTER, KEY_SPACE, KEY_ESCAPE, KEY_TAB, KEY_SHIFT_TAB,
+}
+
+// keysFor returns the keys bound to action, named keys first, then single
+// characters, then modified keys.
+func (k Keymap) keysFor(action Action) []string {
+	rank := func(key string) int {
+		if i := slices.Index(keyOrder, key); i >= 0 {
+			return i
+		}
+		if len([]rune(key)) == 1 {
+			return len(keyOrder)
+		}
+		return len(keyOrder) + 1
+	}
+
+	var keys []string
+	for _, key := range slices.Sorted(maps.Keys(k)) {
+		if k[key] == action {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortStableFunc(keys, func(a, b string) int { return rank(a) - rank(b) })
+	return keys
+}
+
+// keyLabel returns how a key is written in hints and help.
+func keyLabel(key string) string {
+	switch key {
+	case KEY_UP:
+		return theme.G().Up
+	case KEY_DOWN:
+		return theme.G().Down
+	}
+	return key
+}
+
+// keyHints returns a one line summary of the main keys: moving, the hinted
+// actions among actions, the extra actions with a label and help.
+func keyHints(k Keymap, actions []Action, extra []MenuAction) string {
+	var hints []string
+	hint := func(key, label string) {
+		hints = append(hints, keyLabel(key)+" "+label)
+	}
+
+	up, down := k.keysFor(ACTION_UP), k.keysFor(ACTION_DOWN)
+	if len(up) > 0 && len(down) > 0 {
+		hints = append(hints, keyLabel(up[0])+keyLabel(down[0])+" move")
+	}
+	for _, a := range hintActions {
+		if keys := k.keysFor(a); len(keys) > 0 && slices.Contains(actions, a) {
+			label := actionHelp[a].label
+			if a == ACTION_FILTER && quickKeys(extra) {
+				// Typing runs the quick actions instead of filtering
+				label = "starts filtering"
+			}
+			hint(keys[0], label)
+		}
+	}
+	for _, a := range extra {
+		if a.Label != "" {
+			hint(a.Key, a.Label)
+		}
+	}
+	if keys := k.keysFor(ACTION_HELP); len(keys) > 0 {
+		hint(keys[0], actionHelp[ACTION_HELP].label)
+	}
+	return strings.Join(hints, "  ")
+}
+
+// helpLines lists every key bound to actions, then the extra actions.
+func helpLines(title string, k Keymap, actions []Action, extra []MenuAction) []string {
+	type entry struct{ keys, description string }
+	var entries []entry
+	for _, a := range actions {
+		keys := k.keysFor(a)
+		if len(keys) == 0 {
+			continue
+		}
+		labels := make([]string, len(keys))
+		for i, key := range keys {
+			labels[i] = keyLabel(key)
+		}
+		description := actionHelp[a].description
+		if a == ACTION_FILTER && quickKeys(extra) {
+			description = "Filter the items by typing, or start typing a key not listed below"
+		}
+		entries = append(entries, entry{strings.Join(labels, " "), description})
+	}
+	for _, a := range extra {
+		entries = append(entries, entry{keyLabel(a.Key), a.Description})
+	}
+
+	width := 0
+	for _, e := range entries {
+		width = max(width, VisibleWidth(e.keys))
+	}
+	lines := []string{theme.Paint(theme.STYLE_HEADER, title)}
+	for _, e := range entries {
+		lines = append(lines, fmt.Sprintf("  %s  %s", Pad(e.keys, width), e.description))
+	}
+	return append(lines, "", "Press any key to close this help")
+}
+
+// helpActions returns the keymap actions that apply to the menu.
+func (m *Menu) helpActions(multi bool) []Action {
+	var actions []Action
+	for _, a := range Actions {
+		if !multi && a.multiChoice() || a == ACTION_PAUSE && m.Refresh == nil || a == ACTION_SORT && len(m.Columns) == 0 {
+			continue
+		}
+		actions = append(actions, a)
+	}
+	return actions
+}
+
+// helpLines returns the help overlay of the menu, cut to fit the terminal.
+func (m *Menu) helpLines(multi bool) []string {
+	lines := helpLines("Keys for "+m.Prompt, m.Keymap, m.helpActions(multi), m.Actions)
+	if _, height, err := m.Terminal.Size(); err == nil && len(lines) > height-2 {
+		lines = append(lines[:max(height-3, 1)], theme.G().Ellipsis)
+	}
+	return lines
+}
+
+// hints returns the key hints footer of the menu, cut to fit the terminal so
+// it doesn't wrap.
+func (m *Menu) hints(multi bool) string {
+	hints := keyHints(m.Keymap, m.helpActions(multi), m.Actions)
+	if width, _, err := m.Terminal.Size(); err == nil {
+		hints = Truncate(hints, width-4)
+	}
+	return "   " + theme.Paint(theme.STYLE_HINTS, hints)
+}
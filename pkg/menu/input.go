@@ -0,0 +1,58 @@
+package menu
+
+import (
+	"io"
+	"time"
+)
+
+// inputPollInterval is how often input polling checks for a terminal resize,
+// and how long a lone Escape waits to see if it starts an escape sequence
+const inputPollInterval = 50 * time.Millisecond
+
+// keyReader decodes key presses from a terminal in raw mode, buffering any
+// input read beyond the current key so no key presses are lost between reads.
+type keyReader struct {
+	t    Terminal
+	buf  []byte
+	tick time.Duration // how often to return a KEY_TICK key while waiting, 0 for never
+	next time.Time
+}
+
+// ReadKey blocks until a key is pressed, or returns a KEY_RESIZE key when the
+// terminal is resized while waiting, or a KEY_TICK key once per tick interval.
+func (r *keyReader) ReadKey() (Key, error) {
+	if r.tick > 0 && r.next.IsZero() {
+		r.next = time.Now().Add(r.tick)
+	}
+	idle := false
+	chunk := make([]byte, 64)
+	for {
+		// Once no more input arrives, partial sequences such as a lone Escape are final
+		key, n, complete := decodeKey(r.buf, idle)
+		if complete {
+			r.buf = r.buf[n:]
+			if key.Name == "" {
+				// an unrecognised sequence, skip it
+				continue
+			}
+			return key, nil
+		}
+
+		select {
+		case <-r.t.Resized():
+			return Key{Name: KEY_RESIZE}, nil
+		default:
+		}
+		if r.tick > 0 && !time.Now().Before(r.next) {
+			r.next = time.Now().Add(r.tick)
+			return Key{Name: KEY_TICK}, nil
+		}
+
+		read, err := r.t.Read(chunk)
+		if err != nil && err != io.EOF {
+			return Key{}, err
+		}
+		r.buf = append(r.buf, chunk[:read]...)
+		idle = read == 0
+	}
+}
@@ -0,0 +1,152 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Action is what a menu does in response to a key.
+type Action string
+
+const (
+	ACTION_UP        Action = "up"
+	ACTION_DOWN      Action = "down"
+	ACTION_PAGE_UP   Action = "pgup"
+	ACTION_PAGE_DOWN Action = "pgdown"
+	ACTION_HOME      Action = "home"
+	ACTION_END       Action = "end"
+	ACTION_SELECT    Action = "select"
+	ACTION_TOGGLE    Action = "toggle"
+	ACTION_FILTER    Action = "filter"
+	ACTION_BACK      Action = "back"
+	ACTION_INTERRUPT Action = "interrupt"
+	ACTION_HELP      Action = "help"
+
+	// Multi-choice menus only, elsewhere the key starts filtering
+	ACTION_SELECT_ALL  Action = "select_all"
+	ACTION_SELECT_NONE Action = "select_none"
+	ACTION_INVERT      Action = "invert"
+
+	// Refreshing menus only, elsewhere the key starts filtering
+	ACTION_PAUSE Action = "pause"
+
+	// Tables only: asks for the number of the column to sort by
+	ACTION_SORT Action = "sort"
+)
+
+var Actions = []Action{
+	ACTION_UP, ACTION_DOWN, ACTION_PAGE_UP, ACTION_PAGE_DOWN, ACTION_HOME, ACTION_END,
+	ACTION_SELECT, ACTION_TOGGLE, ACTION_FILTER, ACTION_BACK, ACTION_INTERRUPT, ACTION_HELP,
+	ACTION_SELECT_ALL, ACTION_SELECT_NONE, ACTION_INVERT, ACTION_PAUSE, ACTION_SORT,
+}
+
+// multiChoice reports whether the action only applies to multi-choice menus.
+func (a Action) multiChoice() bool {
+	return a == ACTION_TOGGLE || a == ACTION_SELECT_ALL || a == ACTION_SELECT_NONE || a == ACTION_INVERT
+}
+
+// Keymap binds key names, as decoded into Key.Name, to menu actions.
+type Keymap map[string]Action
+
+// DefaultKeymap returns the built in bindings, including vim (j/k/g/G) and
+// Emacs (Ctrl-N/Ctrl-P) style movement.
+func DefaultKeymap() Keymap {
+	return Keymap{
+		KEY_UP:        ACTION_UP,
+		"k":           ACTION_UP,
+		"ctrl+p":      ACTION_UP,
+		KEY_DOWN:      ACTION_DOWN,
+		"j":           ACTION_DOWN,
+		"ctrl+n":      ACTION_DOWN,
+		KEY_PAGE_UP:   ACTION_PAGE_UP,
+		"ctrl+b":      ACTION_PAGE_UP,
+		"alt+v":       ACTION_PAGE_UP,
+		KEY_PAGE_DOWN: ACTION_PAGE_DOWN,
+		"ctrl+f":      ACTION_PAGE_DOWN,
+		"ctrl+v":      ACTION_PAGE_DOWN,
+		KEY_HOME:      ACTION_HOME,
+		"g":           ACTION_HOME,
+		"alt+<":       ACTION_HOME,
+		KEY_END:       ACTION_END,
+		"G":           ACTION_END,
+		"alt+>":       ACTION_END,
+		KEY_ENTER:     ACTION_SELECT,
+		KEY_SPACE:     ACTION_TOGGLE,
+		"a":           ACTION_SELECT_ALL,
+		"n":           ACTION_SELECT_NONE,
+		"i":           ACTION_INVERT,
+		"p":           ACTION_PAUSE,
+		"ctrl+s":      ACTION_SORT,
+		"/":           ACTION_FILTER,
+		"?":           ACTION_HELP,
+		KEY_ESCAPE:    ACTION_BACK,
+		"ctrl+d":      ACTION_BACK,
+		"ctrl+c":      ACTION_INTERRUPT,
+	}
+}
+
+var defaultKeymap = DefaultKeymap()
+
+// SetDefaultKeymap sets the keymap used by menus created afterwards.
+func SetDefaultKeymap(k Keymap) {
+	defaultKeymap = k
+}
+
+// Merge returns a copy of k with the bindings of overrides applied on top.
+func (k Keymap) Merge(overrides Keymap) Keymap {
+	merged := Keymap{}
+	for key, a := range k {
+		merged[key] = a
+	}
+	for key, a := range overrides {
+		if a == "" {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = a
+	}
+	return merged
+}
+
+// ParseKeymap parses comma separated "key=action" bindings, for example
+// "ctrl+j=down,ctrl+k=up,q=back". Binding a key to "none" removes it.
+func ParseKeymap(spec string) (Keymap, error) {
+	k := Keymap{}
+	for _, binding := range strings.Split(spec, ",") {
+		binding = strings.TrimSpace(binding)
+		if binding == "" {
+			continue
+		}
+		key, action, ok := strings.Cut(binding, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid key binding %q, expected key=action", binding)
+		}
+		a, err := ToAction(strings.TrimSpace(action))
+		if err != nil {
+			return nil, err
+		}
+		k[strings.TrimSpace(key)] = a
+	}
+	return k, nil
+}
+
+func ToAction(action string) (Action, error) {
+	if action == "none" {
+		return "", nil
+	}
+	for _, a := range Actions {
+		if string(a) == action {
+			return a, nil
+		}
+	}
+	return "", fmt.Errorf("invalid menu action %q", action)
+}
+
+// keyFor returns the first key bound to action, as ordered by keysFor, or ""
+// when none is.
+func (k Keymap) keyFor(action Action) string {
+	if keys := k.keysFor(action); len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
@@ -0,0 +1,162 @@
+package menu
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Key is a single decoded key press.
+type Key struct {
+	Name string // the key's name in a keymap, e.g. "up", "ctrl+n", "enter" or "j"
+	Rune rune   // the typed character, for printable keys
+}
+
+// Names of keys that aren't printable characters
+const (
+	KEY_UP        = "up"
+	KEY_DOWN      = "down"
+	KEY_LEFT      = "left"
+	KEY_RIGHT     = "right"
+	KEY_HOME      = "home"
+	KEY_END       = "end"
+	KEY_PAGE_UP   = "pgup"
+	KEY_PAGE_DOWN = "pgdown"
+	KEY_DELETE    = "delete"
+	KEY_INSERT    = "insert"
+	KEY_ENTER     = "enter"
+	KEY_TAB       = "tab"
+	KEY_SHIFT_TAB = "shift+tab"
+	KEY_SPACE     = "space"
+	KEY_BACKSPACE = "backspace"
+	KEY_ESCAPE    = "esc"
+	KEY_RESIZE    = "resize" // not a key press, sent when the terminal is resized
+	KEY_TICK      = "tick"   // not a key press, sent at a reader's tick interval
+)
+
+// Raw input keycodes
+const (
+	escape    byte = 27
+	backspace byte = 127
+)
+
+// csiKeys maps the final part of CSI ('<esc>[') and SS3 ('<esc>O') sequences to key names.
+// See: https://en.wikipedia.org/wiki/ANSI_escape_code
+var csiKeys = map[string]string{
+	"A":  KEY_UP,
+	"B":  KEY_DOWN,
+	"C":  KEY_RIGHT,
+	"D":  KEY_LEFT,
+	"Z":  KEY_SHIFT_TAB,
+	"H":  KEY_HOME,
+	"F":  KEY_END,
+	"1~": KEY_HOME,
+	"2~": KEY_INSERT,
+	"3~": KEY_DELETE,
+	"4~": KEY_END,
+	"5~": KEY_PAGE_UP,
+	"6~": KEY_PAGE_DOWN,
+	"7~": KEY_HOME,
+	"8~": KEY_END,
+}
+
+// decodeKey decodes the first key in buf, returning it and the number of
+// bytes it used. When buf only holds the start of a sequence, complete is
+// false and the caller should wait for more input; if none arrives, calling
+// again with final set decodes what is there, such as a lone Escape.
+func decodeKey(buf []byte, final bool) (key Key, n int, complete bool) {
+	if len(buf) == 0 {
+		return Key{}, 0, false
+	}
+
+	b := buf[0]
+	switch {
+	case b == escape:
+		return decodeEscape(buf, final)
+	case b == '\r' || b == '\n':
+		return Key{Name: KEY_ENTER}, 1, true
+	case b == '\t':
+		return Key{Name: KEY_TAB}, 1, true
+	case b == backspace || b == 8:
+		return Key{Name: KEY_BACKSPACE}, 1, true
+	case b == 0:
+		return Key{Name: "ctrl+space"}, 1, true
+	case b < 27:
+		return Key{Name: "ctrl+" + string(rune('a'+b-1))}, 1, true
+	case b == ' ':
+		return Key{Name: KEY_SPACE, Rune: ' '}, 1, true
+	}
+
+	if !utf8.FullRune(buf) && !final {
+		return Key{}, 0, false
+	}
+	r, size := utf8.DecodeRune(buf)
+	if r == utf8.RuneError || !unicode.IsPrint(r) {
+		return Key{}, size, true
+	}
+	return Key{Name: string(r), Rune: r}, size, true
+}
+
+func decodeEscape(buf []byte, final bool) (Key, int, bool) {
+	if len(buf) == 1 {
+		if final {
+			return Key{Name: KEY_ESCAPE}, 1, true
+		}
+		return Key{}, 0, false
+	}
+
+	switch buf[1] {
+	case '[':
+		// CSI: parameter bytes then a single final byte in the range 0x40-0x7E
+		for i := 2; i < len(buf); i++ {
+			c := buf[i]
+			if c >= 0x40 && c <= 0x7E {
+				return Key{Name: csiKeyName(buf[2:i], c)}, i + 1, true
+			}
+			if c < 0x20 || c > 0x3F {
+				// not a valid sequence, drop what was read
+				return Key{}, i, true
+			}
+		}
+		if final {
+			return Key{}, len(buf), true
+		}
+		return Key{}, 0, false
+	case 'O':
+		// SS3: a single final byte
+		if len(buf) < 3 {
+			if final {
+				return Key{Name: "alt+O", Rune: 'O'}, 2, true
+			}
+			return Key{}, 0, false
+		}
+		return Key{Name: csiKeys[string(buf[2])]}, 3, true
+	case escape:
+		return Key{Name: KEY_ESCAPE}, 1, true
+	}
+
+	// Escape followed by a key is how terminals send Alt+key
+	key, n, complete := decodeKey(buf[1:], final)
+	if !complete {
+		return key, 0, false
+	}
+	if key.Name != "" {
+		key.Name = "alt+" + key.Name
+	}
+	return key, n + 1, true
+}
+
+// csiKeyName names a CSI sequence, ignoring any modifier parameter such as
+// the ';5' in '<esc>[1;5A'.
+func csiKeyName(params []byte, final byte) string {
+	p := string(params)
+	for i := 0; i < len(p); i++ {
+		if p[i] == ';' {
+			p = p[:i]
+			break
+		}
+	}
+	if final != '~' {
+		p = ""
+	}
+	return csiKeys[p+string(final)]
+}
@@ -0,0 +1,247 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
+)
+
+// Pane is the content of one region of a full-screen Layout.
+type Pane interface {
+	// Render returns the lines to draw in a region width columns wide and
+	// height rows high. focused is set when the pane receives the keys.
+	Render(width, height int, focused bool) []string
+	// HandleKey reacts to a key pressed while the pane has focus, returning
+	// false when the pane doesn't use the key.
+	HandleKey(key Key) bool
+}
+
+// editor is implemented by panes that take typed text, such as a menu's
+// filter, which then receive keys before the layout does.
+type editor interface {
+	Editing() bool
+}
+
+// Region places a Pane in a Layout, inside a border with a title.
+type Region struct {
+	Title string
+	Pane  Pane
+	// The position and size of the region, border included, set by Arrange
+	X, Y, Width, Height int
+}
+
+// Layout draws regions side by side on the alternate screen, taking over the
+// whole terminal, with a status bar on the last row. Tab and Shift-Tab move
+// the focus between regions.
+type Layout struct {
+	Terminal Terminal
+	Keymap   Keymap
+	Regions  []*Region
+	Focus    int // index of the region receiving keys
+
+	// Arrange positions the regions to fill width x height, the status bar
+	// taking the row below.
+	Arrange func(width, height int)
+	// Status returns the text of the status bar.
+	Status func() string
+	// Tick is how often Update receives a KEY_TICK key, 0 for never.
+	Tick time.Duration
+	// Update receives every key before the focused pane, unless the pane is
+	// editing text. It returns whether it used the key and whether to close
+	// the layout.
+	Update func(key Key) (handled bool, quit bool)
+	// Actions lists the keys Update handles, for the key hints and help
+	Actions []MenuAction
+
+	helping bool // whether the help overlay is shown instead of the regions
+}
+
+// layoutActions are the keymap actions listed in a layout's help
+var layoutActions = []Action{
+	ACTION_UP, ACTION_DOWN, ACTION_PAGE_UP, ACTION_PAGE_DOWN, ACTION_HOME, ACTION_END,
+	ACTION_SELECT, ACTION_FILTER, ACTION_SORT, ACTION_BACK, ACTION_INTERRUPT, ACTION_HELP,
+}
+
+// focusActions move the focus between regions, handled by every layout
+var focusActions = []MenuAction{
+	{Key: KEY_TAB, Label: "next pane", Description: "Move to the next pane"},
+	{Key: KEY_SHIFT_TAB, Description: "Move to the previous pane"},
+}
+
+func NewLayout(regions ...*Region) *Layout {
+	return &Layout{
+		Terminal: defaultTerminal,
+		Keymap:   defaultKeymap,
+		Regions:  regions,
+		Status:   func() string { return "" },
+		Update:   func(Key) (bool, bool) { return false, false },
+	}
+}
+
+// Run shows the layout until Update asks to close it, or returns
+// ErrInterrupted when the user presses the interrupt key.
+func (l *Layout) Run() error {
+	if terminalMode(l.Terminal) != MODE_KEYS {
+		return errors.New("menu: a full-screen layout needs an interactive terminal")
+	}
+	restore, err := l.Terminal.MakeRaw()
+	if err != nil {
+		return err
+	}
+	in := &keyReader{t: l.Terminal, tick: l.Tick}
+
+	// Switch to the alternate screen, leaving the scrollback untouched
+	fmt.Fprint(l.Terminal, "\033[?1049h\033[?25l")
+	defer func() {
+		fmt.Fprint(l.Terminal, "\033[?25h\033[?1049l")
+		restore()
+	}()
+
+	l.draw()
+	for {
+		key, err := in.ReadKey()
+		if err != nil {
+			return err
+		}
+
+		if l.Keymap[key.Name] == ACTION_INTERRUPT {
+			return ErrInterrupted
+		}
+
+		quit := l.handleKey(key)
+		if quit {
+			return nil
+		}
+		l.draw()
+	}
+}
+
+// handleKey passes key to the focused pane and Update, in that order when the
+// pane is editing text and the other way around otherwise.
+func (l *Layout) handleKey(key Key) bool {
+	if key.Name == KEY_RESIZE {
+		return false
+	}
+	if l.helping {
+		// Any key closes the help
+		l.helping = false
+		return false
+	}
+	pane := l.Regions[l.Focus].Pane
+	if e, ok := pane.(editor); ok && e.Editing() {
+		if pane.HandleKey(key) {
+			return false
+		}
+		_, quit := l.Update(key)
+		return quit
+	}
+
+	switch key.Name {
+	case KEY_TAB:
+		l.Focus = (l.Focus + 1) % len(l.Regions)
+		return false
+	case KEY_SHIFT_TAB:
+		l.Focus = (l.Focus + len(l.Regions) - 1) % len(l.Regions)
+		return false
+	}
+	if l.Keymap[key.Name] == ACTION_HELP {
+		l.helping = true
+		return false
+	}
+
+	handled, quit := l.Update(key)
+	if !handled && !quit {
+		pane.HandleKey(key)
+	}
+	return quit
+}
+
+// draw redraws the whole screen.
+func (l *Layout) draw() {
+	width, height, err := l.Terminal.Size()
+	if err != nil {
+		return
+	}
+	if l.helping {
+		lines := helpLines("Keys", l.Keymap, layoutActions, append(slices.Clip(focusActions), l.Actions...))
+		rows := make([]string, height)
+		for y := range rows {
+			if y < len(lines) {
+				rows[y] = " " + lines[y]
+			}
+			rows[y] = Pad(rows[y], width)
+		}
+		fmt.Fprint(l.Terminal, "\033[H"+strings.Join(rows, "\r\n"))
+		return
+	}
+	l.Arrange(width, height-1)
+
+	// Draw each region, then join the regions crossing each row
+	drawn := map[*Region][]string{}
+	for i, r := range l.Regions {
+		drawn[r] = l.drawRegion(r, i == l.Focus)
+	}
+	regions := slices.Clone(l.Regions)
+	slices.SortFunc(regions, func(a, b *Region) int { return a.X - b.X })
+
+	rows := make([]string, 0, height)
+	for y := 0; y < height-1; y++ {
+		var b strings.Builder
+		col := 0
+		for _, r := range regions {
+			if y < r.Y || y >= r.Y+r.Height || r.X < col {
+				continue
+			}
+			b.WriteString(strings.Repeat(" ", r.X-col))
+			b.WriteString(drawn[r][y-r.Y])
+			col = r.X + r.Width
+		}
+		rows = append(rows, Pad(b.String(), width))
+	}
+	rows = append(rows, theme.Paint(theme.STYLE_STATUS_BAR, Pad(l.Status(), width)))
+
+	// Draw from the top left corner, in one write to avoid flickering
+	fmt.Fprint(l.Terminal, "\033[H"+strings.Join(rows, "\r\n"))
+}
+
+// Hints returns a one line summary of the layout's main keys, for the status
+// bar.
+func (l *Layout) Hints() string {
+	return keyHints(l.Keymap, nil, append(slices.Clip(focusActions), l.Actions...))
+}
+
+// drawRegion returns the lines of a region, its pane inside a border.
+func (l *Layout) drawRegion(r *Region, focused bool) []string {
+	if r.Width < 2 || r.Height < 2 {
+		return make([]string, max(r.Height, 0))
+	}
+	inner := r.Width - 2
+	g := theme.G()
+	style := theme.STYLE_BORDER
+	if focused {
+		style = theme.STYLE_BORDER_FOCUSED
+	}
+	border := func(s string) string {
+		return theme.Paint(style, s)
+	}
+
+	title := ""
+	if r.Title != "" {
+		title = Truncate(" "+r.Title+" ", inner-1)
+	}
+	lines := []string{border(g.TopLeft + g.Horizontal + title + strings.Repeat(g.Horizontal, max(inner-1-VisibleWidth(title), 0)) + g.TopRight)}
+
+	content := r.Pane.Render(inner, r.Height-2, focused)
+	for i := 0; i < r.Height-2; i++ {
+		line := ""
+		if i < len(content) {
+			line = content[i]
+		}
+		lines = append(lines, border(g.Vertical)+Pad(line, inner)+border(g.Vertical))
+	}
+	return append(lines, border(g.BottomLeft+strings.Repeat(g.Horizontal, inner)+g.BottomRight))
+}
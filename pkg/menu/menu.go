@@ -1,32 +1,22 @@
 package menu
 
 import (
+	"errors"
 	"fmt"
 	"log"
-	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
-	"github.com/m87wheeler/golang-vercel-cli/pkg/utils"
-
-	"github.com/buger/goterm"
-	"github.com/pkg/term"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
 )
 
-// Raw input keycodes
-var up byte = 65
-var down byte = 66
-var escape byte = 27
-var enter byte = 13
-var space byte = 32
-var keys = map[byte]bool{
-	up:   true,
-	down: true,
-}
-
 func NewMenu(prompt string) *Menu {
 	return &Menu{
 		Prompt:    prompt,
 		MenuItems: make([]*MenuItem, 0),
+		Keymap:    defaultKeymap,
+		Terminal:  defaultTerminal,
 	}
 }
 
@@ -41,80 +31,257 @@ func (m *Menu) AddItem(id string, option string) *Menu {
 	return m
 }
 
+// ErrInterrupted is reported when the user presses the interrupt key, so the
+// program can clean up and quit instead of going back.
+var ErrInterrupted = errors.New("interrupted")
+
 // Display will display the current menu options and awaits user selection
-// It returns the users selected choice
+// Typing, or pressing '/', filters the options by fuzzy matching their text
+// It returns the users selected choice, or "" if they went back, were
+// interrupted or input couldn't be read, the last two of which Err reports
 func (m *Menu) Display() string {
+	choice, _ := m.display(false)
+	return choice
+}
+
+// DisplayMultiChoice displays the menu options with checkboxes, starting with
+// the items marked by Select checked
+// It returns the IDs of the checked options, and false if the user went back,
+// was interrupted or input couldn't be read, the last two of which Err reports
+func (m *Menu) DisplayMultiChoice() ([]string, bool) {
+	if _, ok := m.display(true); !ok {
+		return nil, false
+	}
+	return m.Selected(), true
+}
+
+// Err returns why the menu last stopped reading input, which it treats as
+// going back, or nil if it didn't. It is ErrInterrupted when the user
+// pressed the interrupt key.
+func (m *Menu) Err() error {
+	return m.err
+}
+
+// display shows the menu until the user confirms, returning the ID under the
+// cursor, or goes back, returning false.
+func (m *Menu) display(multi bool) (string, bool) {
+	m.err = nil
+	if ids, ok := m.presetChoice(); ok {
+		if !multi {
+			return ids[0], true
+		}
+		if !m.setSelection(ids) || !m.confirmSelection() {
+			log.Fatalf("invalid value for %s: %s", m.Flag, m.notice)
+		}
+		return ids[0], true
+	}
+
+	switch m.mode() {
+	case MODE_NONE:
+		m.failNonInteractive()
+	case MODE_LINES:
+		return m.displayLines(multi)
+	}
+
+	restore, err := m.Terminal.MakeRaw()
+	if err != nil {
+		m.err = err
+		return "", false
+	}
+	in := &keyReader{t: m.Terminal}
+
+	// Poll for new items in the background while the menu is shown
+	var updates <-chan refreshResult
+	if m.Refresh != nil && m.RefreshInterval > 0 {
+		stop := make(chan struct{})
+		defer close(stop)
+		updates = m.poll(stop)
+		in.tick = refreshTick
+		m.updated = time.Now()
+	}
+
 	defer func() {
+		if restore != nil {
+			restore()
+		}
 		// Show cursor again.
-		fmt.Printf("\033[?25h")
+		fmt.Fprintf(m.Terminal, "\033[?25h")
 	}()
 
-	fmt.Printf("%s\n", goterm.Color(goterm.Bold(m.Prompt)+":", goterm.CYAN))
+	// Raw mode doesn't translate newlines, so every line ends with "\r\n"
+	fmt.Fprintf(m.Terminal, "%s\r\n", theme.Paint(theme.STYLE_PROMPT, m.Prompt+":"))
 
-	m.renderMenuItems(false, false, []string{})
+	m.applyFilter()
+	m.renderMenuItems(false, multi)
 
 	// Turn the terminal cursor off
-	fmt.Printf("\033[?25l")
+	fmt.Fprintf(m.Terminal, "\033[?25l")
 
 	for {
-		keyCode := getInput()
-		switch keyCode {
-		case escape:
-			return ""
-		case enter:
+		key, err := in.ReadKey()
+		if err != nil {
+			// Without input the menu can't be answered, so it goes back
+			m.err = err
+			fmt.Fprint(m.Terminal, "\r\n")
+			return "", false
+		}
+
+		if key.Name == KEY_TICK {
+			if m.tick(updates) {
+				m.renderMenuItems(true, multi)
+			}
+			continue
+		}
+
+		if a, ok := m.extraAction(key.Name); ok && a.Suspend && a.Run != nil && m.quickActions() {
+			if m.cursorVisible() {
+				if restore, err = m.suspend(restore, a, multi); err != nil {
+					m.err = err
+					return "", false
+				}
+			}
+			continue
+		}
+
+		action, redraw := m.handleKey(key, multi)
+		switch action {
+		case ACTION_BACK:
+			return "", false
+		case ACTION_INTERRUPT:
+			fmt.Fprint(m.Terminal, "\r\n")
+			m.err = ErrInterrupted
+			return "", false
+		case ACTION_SELECT:
+			if multi {
+				if !m.confirmSelection() {
+					redraw = true
+					break
+				}
+				fmt.Fprintln(m.Terminal, "\r")
+				if m.cursorVisible() {
+					return m.MenuItems[m.CursorPos].ID, true
+				}
+				return "", true
+			}
+			if !m.cursorVisible() {
+				continue
+			}
 			menuItem := m.MenuItems[m.CursorPos]
-			fmt.Println("\r")
-			return menuItem.ID
-		case up:
-			m.CursorPos = (m.CursorPos + len(m.MenuItems) - 1) % len(m.MenuItems)
-			m.renderMenuItems(true, false, []string{})
-		case down:
-			m.CursorPos = (m.CursorPos + 1) % len(m.MenuItems)
-			m.renderMenuItems(true, false, []string{})
+			fmt.Fprintln(m.Terminal, "\r")
+			return menuItem.ID, true
+		}
+		if redraw {
+			m.renderMenuItems(true, multi)
 		}
 	}
 }
 
-func (m *Menu) DisplayMultiChoice(f func(c string) []string) string {
-	defer func() {
-		// Show cursor again.
-		fmt.Printf("\033[?25h")
-	}()
+// suspend clears the menu and restores the terminal while a runs, then draws
+// the menu again with the message a returned. It returns the function
+// restoring the terminal from the raw mode it is put back in, or nil when
+// raw mode couldn't be entered again.
+func (m *Menu) suspend(restore func() error, a MenuAction, multi bool) (func() error, error) {
+	// Move up to the prompt and clear everything drawn from there
+	fmt.Fprintf(m.Terminal, "\033[%dA\r\033[J\033[?25h", m.renderedLines)
+	restore()
 
-	fmt.Printf("%s\n", goterm.Color(goterm.Bold(m.Prompt)+":", goterm.CYAN))
+	m.notice = a.Run(m.MenuItems[m.CursorPos])
 
-	// Store multi-choice selection
-	selection := f("")
-	m.renderMenuItems(false, true, selection)
+	restore, err := m.Terminal.MakeRaw()
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(m.Terminal, "%s\r\n\033[?25l", theme.Paint(theme.STYLE_PROMPT, m.Prompt+":"))
+	m.renderMenuItems(false, multi)
+	return restore, nil
+}
 
-	// Turn the terminal cursor off
-	fmt.Printf("\033[?25l")
+// quickActions reports whether the keys of extra actions run them, which
+// they only do while the filter is empty and not being edited, so typing a
+// filter is never taken for an action.
+func (m *Menu) quickActions() bool {
+	return !m.filtering && m.filter == "" && !m.helping && !m.sorting
+}
 
-	for {
-		keyCode := getInput()
-		switch keyCode {
-		case escape:
-			return ""
-		case space:
-			menuItem := m.MenuItems[m.CursorPos]
-			selection = f(menuItem.ID)
-			m.renderMenuItems(true, true, selection)
-		case enter:
-			menuItem := m.MenuItems[m.CursorPos]
-			fmt.Println("\r")
-			return menuItem.ID
-		case up:
-			m.CursorPos = (m.CursorPos + len(m.MenuItems) - 1) % len(m.MenuItems)
-			m.renderMenuItems(true, true, selection)
-		case down:
-			m.CursorPos = (m.CursorPos + 1) % len(m.MenuItems)
-			m.renderMenuItems(true, true, selection)
+// handleKey applies a key press to the menu. It returns the action bound to
+// the key when the caller has to act on it (back, interrupt and select), and
+// whether the menu changed and needs redrawing.
+func (m *Menu) handleKey(key Key, multi bool) (Action, bool) {
+	if key.Name == KEY_RESIZE {
+		return "", true
+	}
+	m.notice = ""
+	action := m.Keymap[key.Name]
+	if m.helping {
+		// Any key closes the help
+		m.helping = false
+		if action == ACTION_INTERRUPT {
+			return action, false
+		}
+		return "", true
+	}
+	if m.sorting && action != ACTION_INTERRUPT {
+		return "", m.handleSortKey(key)
+	}
+	if m.filtering && m.handleFilterKey(key) {
+		return "", true
+	}
+	if a, ok := m.extraAction(key.Name); ok && a.Run != nil && !a.Suspend && m.quickActions() {
+		if m.cursorVisible() {
+			m.notice = a.Run(m.MenuItems[m.CursorPos])
 		}
+		return "", true
 	}
+
+	if !multi && action.multiChoice() || action == ACTION_PAUSE && m.Refresh == nil || action == ACTION_SORT && len(m.Columns) == 0 {
+		action = ""
+	}
+
+	switch action {
+	case ACTION_BACK, ACTION_INTERRUPT, ACTION_SELECT:
+		return action, false
+	case ACTION_TOGGLE:
+		m.toggle()
+	case ACTION_SELECT_ALL:
+		m.selectVisible(func(bool) bool { return true })
+	case ACTION_SELECT_NONE:
+		m.selectVisible(func(bool) bool { return false })
+	case ACTION_INVERT:
+		m.selectVisible(func(selected bool) bool { return !selected })
+	case ACTION_PAUSE:
+		m.paused.Store(!m.paused.Load())
+	case ACTION_FILTER:
+		m.filtering = true
+	case ACTION_SORT:
+		m.sorting = true
+	case ACTION_HELP:
+		m.helping = true
+	case ACTION_UP:
+		m.moveCursor(-1)
+	case ACTION_DOWN:
+		m.moveCursor(1)
+	case ACTION_PAGE_UP:
+		m.moveCursorTo(m.cursorRow() - m.pageSize())
+	case ACTION_PAGE_DOWN:
+		m.moveCursorTo(m.cursorRow() + m.pageSize())
+	case ACTION_HOME:
+		m.moveCursorTo(0)
+	case ACTION_END:
+		m.moveCursorTo(len(m.visible) - 1)
+	default:
+		// Typing a character without a binding starts filtering
+		if key.Rune != 0 && key.Rune != ' ' && !m.filtering {
+			m.filtering = true
+			m.handleFilterKey(key)
+			return "", true
+		}
+		return "", false
+	}
+	return action, true
 }
 
 func (m *Menu) DisplayInfoTable(data []InfoTableData) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	w := tabwriter.NewWriter(m.Terminal, 0, 0, 1, ' ', 0)
 
 	// Header row
 	fmt.Fprintln(w, "")
@@ -130,75 +297,139 @@ func (m *Menu) DisplayInfoTable(data []InfoTableData) {
 	w.Flush()
 }
 
-// getInput will read raw input from the terminal
-// It returns the raw ASCII value inputted
-func getInput() byte {
-	t, _ := term.Open("/dev/tty")
-
-	err := term.RawMode(t)
-	if err != nil {
-		log.Fatal(err)
+// DisplayInfoSections prints each section's title above its rows, skipping
+// sections without rows.
+func (m *Menu) DisplayInfoSections(sections []InfoSection) {
+	fmt.Fprintln(m.Terminal)
+	for _, line := range FormatInfoSections(sections) {
+		fmt.Fprintln(m.Terminal, line)
 	}
+	fmt.Fprintln(m.Terminal)
+}
 
-	var read int
-	readBytes := make([]byte, 3)
-	read, err = t.Read(readBytes)
-
-	t.Restore()
-	t.Close()
+// FormatInfoSections returns the lines of sectioned info: each section's
+// title, then its rows indented with their values aligned.
+func FormatInfoSections(sections []InfoSection) []string {
+	var lines []string
+	for _, s := range sections {
+		if len(s.Rows) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, theme.Paint(theme.STYLE_HEADER, s.Title))
 
-	// Arrow keys are prefixed with the ANSI escape code which take up the first two bytes.
-	// The third byte is the key specific value we are looking for.
-	// For example the left arrow key is '<esc>[A' while the right is '<esc>[C'
-	// See: https://en.wikipedia.org/wiki/ANSI_escape_code
-	if read == 3 {
-		if _, ok := keys[readBytes[2]]; ok {
-			return readBytes[2]
+		width := 0
+		for _, r := range s.Rows {
+			width = max(width, VisibleWidth(r.Label))
+		}
+		for _, r := range s.Rows {
+			lines = append(lines, "  "+Pad(r.Label, width)+"  "+r.Data)
 		}
-	} else {
-		return readBytes[0]
 	}
-
-	return 0
+	return lines
 }
 
-// renderMenuItems prints the menu item list.
+// renderMenuItems prints the menu items matching the current filter.
 // Setting redraw to true will re-render the options list with updated current selection.
-func (m *Menu) renderMenuItems(redraw bool, multi bool, selection []string) {
-	if redraw {
-		// Move the cursor up n lines where n is the number of options, setting the new
-		// location to start printing from, effectively redrawing the option list
+func (m *Menu) renderMenuItems(redraw bool, multi bool) {
+	if redraw && m.renderedLines > 1 {
+		// Move the cursor up n lines where n is the number of lines last drawn, setting
+		// the new location to start printing from, effectively redrawing the option list
 		//
 		// This is done by sending a VT100 escape code to the terminal
 		// @see http://www.climagic.org/mirrors/VT100_Escape_Codes.html
-		fmt.Printf("\033[%dA", len(m.MenuItems)-1)
+		fmt.Fprintf(m.Terminal, "\033[%dA", m.renderedLines-1)
+	}
+	// Clear the previous drawing, which may have had more lines
+	fmt.Fprintf(m.Terminal, "\r\033[J")
+
+	if len(m.Columns) > 0 {
+		// Fit the table to the terminal, which may have been resized
+		width, _, _ := m.Terminal.Size()
+		m.layoutColumns(width)
+		m.applyFilter()
 	}
 
-	for index, menuItem := range m.MenuItems {
-		var newline = "\n"
-		if index == len(m.MenuItems)-1 {
-			// Adding a new line on the last option will move the cursor position out of range
-			// For out redrawing
-			newline = ""
-		}
+	lines := m.itemLines(multi)
+	if !m.helping {
+		lines = append(lines, m.hints(multi))
+	}
 
-		menuItemText := menuItem.Text
-		cursor := "  "
-		checkbox := "\u2610"
-		if utils.Contains(selection, menuItemText) {
-			checkbox = "\u2612"
+	// The last line has no newline, keeping the cursor in range for redrawing
+	fmt.Fprint(m.Terminal, strings.Join(lines, "\r\n"))
+	m.renderedLines = len(lines)
+}
+
+// itemLines returns the lines drawing the items in the viewport, with the
+// scroll markers, filter and selection status around them.
+func (m *Menu) itemLines(multi bool) []string {
+	if m.helping {
+		return m.helpLines(multi)
+	}
+	var lines []string
+	if len(m.Columns) > 0 {
+		lines = append(lines, m.tableHeader())
+	}
+	start, end := m.viewport()
+	if start > 0 {
+		lines = append(lines, scrollMarker(theme.G().Up, start))
+	}
+	for _, index := range m.visible[start:end] {
+		menuItem := m.MenuItems[index]
+
+		g := theme.G()
+		cursor := strings.Repeat(" ", VisibleWidth(g.Cursor))
+		checkbox := g.Unchecked
+		if m.selected[menuItem.ID] {
+			checkbox = g.Checked
 		}
 
+		textStyle := ""
 		if index == m.CursorPos {
-			checkbox = goterm.Color(checkbox, goterm.YELLOW)
-			cursor = goterm.Color("> ", goterm.YELLOW)
-			menuItemText = goterm.Color(menuItemText, goterm.YELLOW)
+			checkbox = theme.Paint(theme.STYLE_CURSOR, checkbox)
+			cursor = theme.Paint(theme.STYLE_CURSOR, g.Cursor)
+			textStyle = theme.STYLE_CURSOR
+		}
+		menuItemText := highlight(menuItem.Text, m.matches[index], textStyle)
+		if _, ok := m.flashes[menuItem.ID]; ok {
+			// Items whose state just changed are drawn in the flash style
+			menuItemText = theme.Paint(theme.STYLE_FLASH, menuItem.Text)
 		}
 
 		if multi {
-			fmt.Printf("\r%s %s %s%s", cursor, checkbox, menuItemText, newline)
+			lines = append(lines, fmt.Sprintf("%s %s %s", cursor, checkbox, menuItemText))
 		} else {
-			fmt.Printf("\r%s %s%s", cursor, menuItemText, newline)
+			lines = append(lines, fmt.Sprintf("%s %s", cursor, menuItemText))
+		}
+	}
+
+	if end < len(m.visible) {
+		lines = append(lines, scrollMarker(theme.G().Down, len(m.visible)-end))
+	}
+	if len(m.visible) == 0 {
+		lines = append(lines, "   No matches")
+	}
+	if m.filtering {
+		lines = append(lines, theme.Paint(theme.STYLE_FILTER, "/"+m.filter)+
+			fmt.Sprintf("  (%d/%d)", len(m.visible), len(m.MenuItems)))
+	}
+	if m.sorting {
+		lines = append(lines, theme.Paint(theme.STYLE_FILTER, fmt.Sprintf("Sort by column 1-%d", min(len(m.Columns), 9)))+
+			"  (any other key to cancel)")
+	}
+	if m.Refresh != nil {
+		lines = append(lines, m.refreshStatus())
+	}
+	if multi {
+		status := "   " + m.selectionStatus()
+		if m.notice != "" {
+			status += "  " + theme.Paint(theme.STYLE_NOTICE, m.notice)
 		}
+		lines = append(lines, status)
+	} else if m.notice != "" {
+		lines = append(lines, "   "+theme.Paint(theme.STYLE_NOTICE, m.notice))
 	}
+	return lines
 }
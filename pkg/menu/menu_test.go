@@ -0,0 +1,327 @@
+package menu_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu/menutest"
+)
+
+// fruitMenu returns a menu of three items drawn to vt.
+func fruitMenu(vt *menutest.VirtualTerminal) *menu.Menu {
+	m := menu.NewMenu("Pick a fruit")
+	m.Terminal = vt
+	m.AddItem("a", "apple").AddItem("b", "banana").AddItem("c", "cherry")
+	return m
+}
+
+func TestDisplay(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+		want string
+	}{
+		{name: "select first", keys: []string{menutest.Enter}, want: "a"},
+		{name: "move down", keys: []string{menutest.Down, menutest.Down, menutest.Enter}, want: "c"},
+		{name: "vim keys", keys: []string{"j", "j", "k", menutest.Enter}, want: "b"},
+		{name: "end", keys: []string{menutest.End, menutest.Enter}, want: "c"},
+		{name: "up wraps to the bottom", keys: []string{menutest.Up, menutest.Enter}, want: "c"},
+		{name: "filter by typing", keys: []string{"c", "h", menutest.Enter}, want: "c"},
+		{name: "filter with slash", keys: []string{"/", "b", "n", menutest.Enter}, want: "b"},
+		{name: "filter then move", keys: []string{"/", "a", menutest.Down, menutest.Enter}, want: "b"},
+		{name: "escape clears the filter, keeping the cursor", keys: []string{"/", "c", "h", menutest.Escape, menutest.Down, menutest.Enter}, want: "a"},
+		{name: "no match selects nothing", keys: []string{"/", "x", "y", "z", menutest.Enter, menutest.Escape, menutest.Escape}, want: ""},
+		{name: "back", keys: []string{menutest.Escape}, want: ""},
+		{name: "back with ctrl+d", keys: []string{menutest.CtrlD}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vt := menutest.NewVirtualTerminal(80, 24).Type(tt.keys...)
+			m := fruitMenu(vt)
+			if got := m.Display(); got != tt.want {
+				t.Errorf("Display() = %q, want %q\nscreen:\n%s", got, tt.want, vt.Screen())
+			}
+			if err := m.Err(); err != nil {
+				t.Errorf("Err() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestDisplayInputExhausted(t *testing.T) {
+	vt := menutest.NewVirtualTerminal(80, 24).Type(menutest.Down)
+	m := fruitMenu(vt)
+	if got := m.Display(); got != "" {
+		t.Errorf("Display() = %q, want \"\"", got)
+	}
+	if err := m.Err(); !errors.Is(err, menutest.ErrInputExhausted) {
+		t.Errorf("Err() = %v, want %v", err, menutest.ErrInputExhausted)
+	}
+}
+
+func TestInterrupted(t *testing.T) {
+	for _, keys := range [][]string{
+		{menutest.CtrlC},
+		{"/", "b", menutest.CtrlC},
+		{"?", menutest.CtrlC},
+	} {
+		vt := menutest.NewVirtualTerminal(80, 24).Type(keys...)
+		m := fruitMenu(vt)
+		if got := m.Display(); got != "" {
+			t.Errorf("Display() after %q = %q, want \"\"", keys, got)
+		}
+		if err := m.Err(); !errors.Is(err, menu.ErrInterrupted) {
+			t.Errorf("Err() after %q = %v, want %v", keys, err, menu.ErrInterrupted)
+		}
+	}
+
+	vt := menutest.NewVirtualTerminal(80, 24).Type(menutest.CtrlC)
+	m := fruitMenu(vt)
+	if _, ok := m.DisplayMultiChoice(); ok || !errors.Is(m.Err(), menu.ErrInterrupted) {
+		t.Errorf("DisplayMultiChoice() = %v, Err() = %v, want false, %v", ok, m.Err(), menu.ErrInterrupted)
+	}
+
+	menu.SetDefaultTerminal(menutest.NewVirtualTerminal(80, 24).Type("w", menutest.CtrlC))
+	if got, err := menu.Confirm("Cancel?", "web"); got || !errors.Is(err, menu.ErrInterrupted) {
+		t.Errorf("Confirm() = %v, %v, want false, %v", got, err, menu.ErrInterrupted)
+	}
+
+	vt = menutest.NewVirtualTerminal(80, 24).Type(menutest.Down, menutest.CtrlC)
+	l := menu.NewLayout(&menu.Region{Title: "Text", Pane: menu.NewTextPane()})
+	l.Terminal = vt
+	l.Arrange = func(width, height int) {
+		r := l.Regions[0]
+		r.Width, r.Height = width, height
+	}
+	if err := l.Run(); !errors.Is(err, menu.ErrInterrupted) {
+		t.Errorf("Layout.Run() = %v, want %v\nscreen:\n%s", err, menu.ErrInterrupted, vt.Screen())
+	}
+}
+
+func TestDisplayDraws(t *testing.T) {
+	vt := menutest.NewVirtualTerminal(80, 24).Type(menutest.Down, "/", "c", menutest.Enter)
+	fruitMenu(vt).Display()
+
+	frames := vt.Frames()
+	if len(frames) < 3 {
+		t.Fatalf("got %d frames, want at least 3", len(frames))
+	}
+	first := frames[0]
+	for _, want := range []string{"Pick a fruit:", "apple", "banana", "cherry"} {
+		if !strings.Contains(first, want) {
+			t.Errorf("first frame is missing %q:\n%s", want, first)
+		}
+	}
+	filtered := frames[len(frames)-1]
+	if strings.Contains(filtered, "banana") || !strings.Contains(filtered, "cherry") {
+		t.Errorf("filtering by \"c\" should leave only cherry:\n%s", filtered)
+	}
+}
+
+func TestDisplayMultiChoice(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max int
+		selected []string
+		keys     []string
+		want     []string
+		wantOK   bool
+	}{
+		{name: "none", keys: []string{menutest.Enter}, want: []string{}, wantOK: true},
+		{name: "toggle", keys: []string{menutest.Space, menutest.Down, menutest.Down, menutest.Space, menutest.Enter}, want: []string{"a", "c"}, wantOK: true},
+		{name: "toggle off", selected: []string{"a", "b"}, keys: []string{menutest.Space, menutest.Enter}, want: []string{"b"}, wantOK: true},
+		{name: "select all", keys: []string{"a", menutest.Enter}, want: []string{"a", "b", "c"}, wantOK: true},
+		{name: "select none", selected: []string{"a", "c"}, keys: []string{"n", menutest.Enter}, want: []string{}, wantOK: true},
+		{name: "invert", selected: []string{"b"}, keys: []string{"i", menutest.Enter}, want: []string{"a", "c"}, wantOK: true},
+		{name: "select all filtered", keys: []string{"/", "e", "r", menutest.Escape, "a", menutest.Enter}, want: []string{"a", "b", "c"}, wantOK: true},
+		{name: "min blocks confirming", min: 1, keys: []string{menutest.Enter}, wantOK: false},
+		{name: "min met", min: 1, keys: []string{menutest.Enter, menutest.Space, menutest.Enter}, want: []string{"a"}, wantOK: true},
+		{name: "max blocks toggling", max: 1, keys: []string{menutest.Space, menutest.Down, menutest.Space, menutest.Enter}, want: []string{"a"}, wantOK: true},
+		{name: "max blocks select all", max: 2, keys: []string{"a", menutest.Enter}, want: []string{}, wantOK: true},
+		{name: "back", selected: []string{"a"}, keys: []string{menutest.Escape}, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vt := menutest.NewVirtualTerminal(80, 24).Type(tt.keys...)
+			m := fruitMenu(vt)
+			m.MinSelected, m.MaxSelected = tt.min, tt.max
+			m.Select(tt.selected...)
+
+			got, ok := m.DisplayMultiChoice()
+			if ok != tt.wantOK {
+				t.Fatalf("DisplayMultiChoice() ok = %v, want %v\nscreen:\n%s", ok, tt.wantOK, vt.Screen())
+			}
+			if ok && !slices.Equal(got, tt.want) {
+				t.Errorf("DisplayMultiChoice() = %q, want %q\nscreen:\n%s", got, tt.want, vt.Screen())
+			}
+		})
+	}
+}
+
+func TestDisplayMultiChoiceNotices(t *testing.T) {
+	vt := menutest.NewVirtualTerminal(80, 24).Type(menutest.Enter)
+	m := fruitMenu(vt)
+	m.MinSelected = 2
+	m.DisplayMultiChoice()
+
+	if !strings.Contains(vt.Screen(), "Choose at least 2") {
+		t.Errorf("confirming too few should explain why:\n%s", vt.Screen())
+	}
+}
+
+func TestDisplayInfoTable(t *testing.T) {
+	vt := menutest.NewVirtualTerminal(80, 24)
+	m := menu.NewMenu("")
+	m.Terminal = vt
+	m.DisplayInfoTable([]menu.InfoTableData{
+		{Label: "ID", Data: "dpl_123"},
+		{Label: "State", Data: "READY"},
+	})
+
+	screen := vt.Screen()
+	for _, want := range []string{"Field", "Value", "ID", "dpl_123", "State", "READY"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("info table is missing %q:\n%s", want, screen)
+		}
+	}
+	// Labels and values line up in columns
+	var idCol, stateCol int
+	for _, line := range strings.Split(screen, "\n") {
+		if strings.HasPrefix(line, "ID") {
+			idCol = strings.Index(line, "dpl_123")
+		}
+		if strings.HasPrefix(line, "State") {
+			stateCol = strings.Index(line, "READY")
+		}
+	}
+	if idCol == 0 || idCol != stateCol {
+		t.Errorf("values aren't aligned (%d, %d):\n%s", idCol, stateCol, screen)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect string
+		keys   []string
+		want   bool
+	}{
+		{name: "yes", keys: []string{"y"}, want: true},
+		{name: "capital yes", keys: []string{"Y"}, want: true},
+		{name: "no", keys: []string{"n"}, want: false},
+		{name: "any other key", keys: []string{menutest.Enter}, want: false},
+		{name: "escape", keys: []string{menutest.Escape}, want: false},
+		{name: "typed", expect: "web", keys: []string{"w", "e", "b", menutest.Enter}, want: true},
+		{name: "typed with a correction", expect: "web", keys: []string{"w", "x", menutest.Backspace, "e", "b", menutest.Enter}, want: true},
+		{name: "typed wrong", expect: "web", keys: []string{"w", "e", menutest.Enter}, want: false},
+		{name: "input exhausted", expect: "web", keys: []string{"w"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vt := menutest.NewVirtualTerminal(80, 24).Type(tt.keys...)
+			menu.SetDefaultTerminal(vt)
+			got, err := menu.Confirm("Cancel?", tt.expect)
+			if got != tt.want || err != nil {
+				t.Errorf("Confirm() = %v, %v, want %v, nil\nscreen:\n%s", got, err, tt.want, vt.Screen())
+			}
+		})
+	}
+}
+
+func TestDisplayQuickActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    []string
+		want    string
+		wantRan bool
+	}{
+		{name: "key runs the action", keys: []string{"c", menutest.Enter}, want: "a", wantRan: true},
+		{name: "slash filters by the key", keys: []string{"/", "c", menutest.Enter}, want: "c"},
+		{name: "typed filter takes the key", keys: []string{"h", "e", "r", "r", menutest.Enter}, want: "c"},
+		{name: "key runs again once the filter is cleared", keys: []string{"/", "b", menutest.Escape, "c", menutest.Enter}, want: "b", wantRan: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vt := menutest.NewVirtualTerminal(80, 24).Type(tt.keys...)
+			m := fruitMenu(vt)
+			ran := false
+			m.AddAction(menu.MenuAction{Key: "c", Label: "count", Run: func(*menu.MenuItem) string {
+				ran = true
+				return ""
+			}})
+			if got := m.Display(); got != tt.want {
+				t.Errorf("Display() = %q, want %q\nscreen:\n%s", got, tt.want, vt.Screen())
+			}
+			if ran != tt.wantRan {
+				t.Errorf("action ran = %v, want %v", ran, tt.wantRan)
+			}
+			if !strings.Contains(vt.Frames()[0], "/ starts filtering") {
+				t.Errorf("hints should say / starts filtering:\n%s", vt.Frames()[0])
+			}
+		})
+	}
+}
+
+func TestDisplaySort(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+		want string
+	}{
+		{name: "sort by the second column", keys: []string{menutest.CtrlS, "2", menutest.Home, menutest.Enter}, want: "c"},
+		{name: "again to reverse", keys: []string{menutest.CtrlS, "2", menutest.CtrlS, "2", menutest.Home, menutest.Enter}, want: "b"},
+		{name: "other keys cancel", keys: []string{menutest.CtrlS, "x", menutest.Enter}, want: "a"},
+		{name: "escape cancels without going back", keys: []string{menutest.CtrlS, menutest.Escape, menutest.Down, menutest.Enter}, want: "b"},
+		{name: "digits filter", keys: []string{"3", menutest.Enter}, want: "b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vt := menutest.NewVirtualTerminal(80, 24).Type(tt.keys...)
+			m := menu.NewMenu("Pick a fruit")
+			m.Terminal = vt
+			m.Columns = []menu.Column{{Name: "Name"}, {Name: "Count"}}
+			m.MenuItems = []*menu.MenuItem{
+				{ID: "a", Cells: []string{"apple", "2"}},
+				{ID: "b", Cells: []string{"banana", "3"}},
+				{ID: "c", Cells: []string{"cherry", "1"}},
+			}
+			if got := m.Display(); got != tt.want {
+				t.Errorf("Display() = %q, want %q\nscreen:\n%s", got, tt.want, vt.Screen())
+			}
+		})
+	}
+}
+
+func TestConfirmKeymap(t *testing.T) {
+	const ctrlQ = "\x11"
+	tests := []struct {
+		name   string
+		expect string
+		keys   []string
+		want   bool
+	}{
+		{name: "remapped back", keys: []string{ctrlQ}, want: false},
+		{name: "remapped back while typing", expect: "web", keys: []string{"w", ctrlQ, "e", "b", menutest.Enter}, want: false},
+		{name: "unbound escape is ignored while typing", expect: "web", keys: []string{menutest.Escape, "w", "e", "b", menutest.Enter}, want: true},
+		{name: "bound characters are typed", expect: "quit", keys: []string{"q", "u", "i", "t", menutest.Enter}, want: true},
+	}
+	menu.SetDefaultKeymap(menu.DefaultKeymap().Merge(menu.Keymap{
+		"ctrl+q":        menu.ACTION_BACK,
+		menu.KEY_ESCAPE: "",
+		"q":             menu.ACTION_BACK,
+	}))
+	t.Cleanup(func() { menu.SetDefaultKeymap(menu.DefaultKeymap()) })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vt := menutest.NewVirtualTerminal(80, 24).Type(tt.keys...)
+			menu.SetDefaultTerminal(vt)
+			got, err := menu.Confirm("Cancel?", tt.expect)
+			if got != tt.want || err != nil {
+				t.Errorf("Confirm() = %v, %v, want %v, nil\nscreen:\n%s", got, err, tt.want, vt.Screen())
+			}
+		})
+	}
+}
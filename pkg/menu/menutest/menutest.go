@@ -0,0 +1,326 @@
+// Package menutest provides a virtual terminal for testing code built on the
+// menu package, scripting key presses and capturing what is drawn.
+package menutest
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Key presses for use with Type
+const (
+	Up        = "\x1b[A"
+	Down      = "\x1b[B"
+	PageUp    = "\x1b[5~"
+	PageDown  = "\x1b[6~"
+	Home      = "\x1b[H"
+	End       = "\x1b[F"
+	Enter     = "\r"
+	Escape    = "\x1b"
+	Space     = " "
+	Backspace = "\x7f"
+	CtrlC     = "\x03"
+	CtrlD     = "\x04"
+	CtrlN     = "\x0e"
+	CtrlP     = "\x10"
+	CtrlS     = "\x13"
+)
+
+// ErrInputExhausted is returned by Read once every scripted key has been read.
+var ErrInputExhausted = errors.New("menutest: no more scripted input")
+
+type resizeSignal struct{}
+
+func (resizeSignal) String() string { return "resize" }
+func (resizeSignal) Signal()        {}
+
+type event struct {
+	input         []byte
+	width, height int // set for resize events
+}
+
+// VirtualTerminal implements menu.Terminal. It replays scripted input and
+// emulates enough of a VT100 to reconstruct the screen from what is written.
+type VirtualTerminal struct {
+	mu      sync.Mutex
+	width   int
+	height  int
+	raw     bool
+	events  []event
+	idle    bool // the last read returned a key press, so the next one is idle
+	resized chan os.Signal
+
+	output  strings.Builder
+	lines   [][]rune
+	row     int
+	col     int
+	drawn   bool // output was written since the last frame was captured
+	frames  []string
+	partial []byte       // an incomplete escape sequence or rune from the last write
+	main    *screenState // the main screen, saved while the alternate screen is shown
+}
+
+// screenState is the content and cursor of a screen.
+type screenState struct {
+	lines    [][]rune
+	row, col int
+}
+
+// NewVirtualTerminal returns a virtual terminal of the given size.
+func NewVirtualTerminal(width, height int) *VirtualTerminal {
+	return &VirtualTerminal{
+		width:   width,
+		height:  height,
+		resized: make(chan os.Signal, 1),
+		lines:   [][]rune{{}},
+	}
+}
+
+// Type queues key presses, each delivered by its own read as if typed one at
+// a time. Use the constants of this package for special keys.
+func (vt *VirtualTerminal) Type(keys ...string) *VirtualTerminal {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+	for _, k := range keys {
+		vt.events = append(vt.events, event{input: []byte(k)})
+	}
+	return vt
+}
+
+// Resize queues a resize of the terminal, after any keys already queued.
+func (vt *VirtualTerminal) Resize(width, height int) *VirtualTerminal {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+	vt.events = append(vt.events, event{width: width, height: height})
+	return vt
+}
+
+func (vt *VirtualTerminal) Read(p []byte) (int, error) {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+
+	if !vt.raw {
+		return 0, errors.New("menutest: read while not in raw mode")
+	}
+
+	// Reading means the program is waiting for input, so what it drew is a frame
+	if vt.drawn {
+		vt.frames = append(vt.frames, vt.screen())
+		vt.drawn = false
+	}
+
+	// An idle read between key presses lets a lone Escape be told apart from a sequence
+	if vt.idle {
+		vt.idle = false
+		return 0, nil
+	}
+	if len(vt.events) == 0 {
+		return 0, ErrInputExhausted
+	}
+
+	e := vt.events[0]
+	vt.events = vt.events[1:]
+	vt.idle = true
+	if e.input == nil {
+		vt.width, vt.height = e.width, e.height
+		vt.resized <- resizeSignal{}
+		return 0, nil
+	}
+	return copy(p, e.input), nil
+}
+
+func (vt *VirtualTerminal) Write(p []byte) (int, error) {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+
+	vt.output.Write(p)
+	vt.drawn = true
+	vt.interpret(append(vt.partial, p...))
+	return len(p), nil
+}
+
+func (vt *VirtualTerminal) Size() (int, int, error) {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+	return vt.width, vt.height, nil
+}
+
+func (vt *VirtualTerminal) MakeRaw() (func() error, error) {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+	vt.raw = true
+	return func() error {
+		vt.mu.Lock()
+		defer vt.mu.Unlock()
+		vt.raw = false
+		return nil
+	}, nil
+}
+
+func (vt *VirtualTerminal) Resized() <-chan os.Signal {
+	return vt.resized
+}
+
+// Output returns everything written, including escape sequences.
+func (vt *VirtualTerminal) Output() string {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+	return vt.output.String()
+}
+
+// Screen returns the text currently on screen, without colours and with
+// trailing spaces removed from each line.
+func (vt *VirtualTerminal) Screen() string {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+	return vt.screen()
+}
+
+// Frames returns the screen as it was each time the program waited for input.
+func (vt *VirtualTerminal) Frames() []string {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+	return append([]string{}, vt.frames...)
+}
+
+func (vt *VirtualTerminal) screen() string {
+	lines := make([]string, len(vt.lines))
+	for i, l := range vt.lines {
+		lines[i] = strings.TrimRight(string(l), " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// interpret applies written output to the screen. The screen is a scrollback
+// of lines that grows as the cursor moves down, which suits inline menus.
+func (vt *VirtualTerminal) interpret(b []byte) {
+	vt.partial = nil
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b:
+			n, ok := vt.escape(b)
+			if !ok {
+				vt.partial = append([]byte{}, b...)
+				return
+			}
+			b = b[n:]
+		case c == '\r':
+			vt.col = 0
+			b = b[1:]
+		case c == '\b':
+			if vt.col > 0 {
+				vt.col--
+			}
+			b = b[1:]
+		case c == '\n':
+			vt.row++
+			if !vt.raw {
+				// the terminal driver adds a carriage return outside of raw mode
+				vt.col = 0
+			}
+			for len(vt.lines) <= vt.row {
+				vt.lines = append(vt.lines, []rune{})
+			}
+			b = b[1:]
+		case c < 0x20:
+			b = b[1:]
+		default:
+			if !utf8.FullRune(b) {
+				vt.partial = append([]byte{}, b...)
+				return
+			}
+			r, n := utf8.DecodeRune(b)
+			vt.put(r)
+			b = b[n:]
+		}
+	}
+}
+
+func (vt *VirtualTerminal) put(r rune) {
+	line := vt.lines[vt.row]
+	for len(line) <= vt.col {
+		line = append(line, ' ')
+	}
+	line[vt.col] = r
+	vt.lines[vt.row] = line
+	vt.col++
+}
+
+// escape applies the escape sequence at the start of b, returning its length,
+// or false when b ends before the sequence does.
+func (vt *VirtualTerminal) escape(b []byte) (int, bool) {
+	if len(b) < 2 {
+		return 0, false
+	}
+	if b[1] != '[' {
+		return 2, true
+	}
+
+	for i := 2; i < len(b); i++ {
+		c := b[i]
+		if c < 0x40 || c > 0x7E {
+			continue
+		}
+		params := string(b[2:i])
+		n, err := strconv.Atoi(params)
+		if err != nil {
+			n = 1
+		}
+
+		switch c {
+		case 'H':
+			// move to a row and column, counted from 1, of the screen
+			row, col, _ := strings.Cut(params, ";")
+			vt.row, vt.col = max(atoi(row, 1)-1, 0), max(atoi(col, 1)-1, 0)
+			for len(vt.lines) <= vt.row {
+				vt.lines = append(vt.lines, []rune{})
+			}
+		case 'h', 'l':
+			// switch to and from the alternate screen, which starts empty
+			if params == "?1049" && c == 'h' && vt.main == nil {
+				vt.main = &screenState{lines: vt.lines, row: vt.row, col: vt.col}
+				vt.lines, vt.row, vt.col = [][]rune{{}}, 0, 0
+			} else if params == "?1049" && c == 'l' && vt.main != nil {
+				vt.lines, vt.row, vt.col = vt.main.lines, vt.main.row, vt.main.col
+				vt.main = nil
+			}
+		case 'A':
+			vt.row = max(vt.row-n, 0)
+		case 'B':
+			vt.row += n
+			for len(vt.lines) <= vt.row {
+				vt.lines = append(vt.lines, []rune{})
+			}
+		case 'C':
+			vt.col += n
+		case 'D':
+			vt.col = max(vt.col-n, 0)
+		case 'J':
+			// clear from the cursor to the end of the screen
+			if vt.col < len(vt.lines[vt.row]) {
+				vt.lines[vt.row] = vt.lines[vt.row][:vt.col]
+			}
+			vt.lines = vt.lines[:vt.row+1]
+		case 'K':
+			if vt.col < len(vt.lines[vt.row]) {
+				vt.lines[vt.row] = vt.lines[vt.row][:vt.col]
+			}
+		}
+		// colours and modes, such as hiding the cursor, don't affect the text
+		return i + 1, true
+	}
+	return 0, false
+}
+
+// atoi parses a numeric escape sequence parameter, returning def when it's omitted.
+func atoi(s string, def int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,199 @@
+package menu
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	xterm "golang.org/x/term"
+)
+
+// Mode controls how menus take input.
+type Mode int
+
+const (
+	// MODE_AUTO reads key presses when stdin is a terminal and falls back to
+	// numbered prompts read line by line otherwise
+	MODE_AUTO Mode = iota
+	// MODE_KEYS always reads key presses from the terminal
+	MODE_KEYS
+	// MODE_LINES always shows numbered prompts read line by line
+	MODE_LINES
+	// MODE_NONE never prompts, a menu without a preset value exits with an
+	// error naming the flag to pass instead
+	MODE_NONE
+)
+
+var defaultMode = MODE_AUTO
+
+// SetMode sets how every menu takes input.
+func SetMode(mode Mode) {
+	defaultMode = mode
+}
+
+var lineInput = bufio.NewReader(os.Stdin)
+
+// SetLineInput sets where numbered prompts read their answers from.
+func SetLineInput(r io.Reader) {
+	lineInput = bufio.NewReader(r)
+}
+
+var presets = map[string]string{}
+
+// Preset answers the next menu whose Flag is flag with value, which is matched
+// against the menu items' IDs and then their text. Multi-choice menus take
+// comma separated values.
+func Preset(flag, value string) {
+	presets[flag] = value
+}
+
+// mode returns how this menu takes input.
+func (m *Menu) mode() Mode {
+	return terminalMode(m.Terminal)
+}
+
+// terminalMode returns how input is taken on t, resolving MODE_AUTO.
+func terminalMode(t Terminal) Mode {
+	if defaultMode != MODE_AUTO {
+		return defaultMode
+	}
+	if _, ok := t.(*TTY); ok && !xterm.IsTerminal(int(os.Stdin.Fd())) {
+		return MODE_LINES
+	}
+	return MODE_KEYS
+}
+
+// presetChoice returns the IDs of the items chosen by a preset for this menu,
+// consuming the preset so a menu shown again asks the user.
+func (m *Menu) presetChoice() ([]string, bool) {
+	if m.Flag == "" {
+		return nil, false
+	}
+	value, ok := presets[m.Flag]
+	if !ok {
+		return nil, false
+	}
+	delete(presets, m.Flag)
+
+	var ids []string
+	for _, v := range strings.Split(value, ",") {
+		item := m.findItem(strings.TrimSpace(v))
+		if item == nil {
+			log.Fatalf("invalid value %q for %s, choose from: %s", v, m.Flag, strings.Join(m.itemIDs(), ", "))
+		}
+		ids = append(ids, item.ID)
+	}
+	return ids, true
+}
+
+// failNonInteractive exits explaining how to answer this menu without prompting.
+func (m *Menu) failNonInteractive() {
+	if m.Flag == "" {
+		log.Fatalf("%q needs input, which isn't allowed with --no-interactive", m.Prompt)
+	}
+	log.Fatalf("%q needs input, which isn't allowed with --no-interactive; pass %s <value> instead", m.Prompt, m.Flag)
+}
+
+func (m *Menu) findItem(v string) *MenuItem {
+	for _, item := range m.MenuItems {
+		if item.ID == v {
+			return item
+		}
+	}
+	for _, item := range m.MenuItems {
+		if strings.EqualFold(item.Text, v) {
+			return item
+		}
+	}
+	return nil
+}
+
+func (m *Menu) itemIDs() []string {
+	ids := make([]string, len(m.MenuItems))
+	for i, item := range m.MenuItems {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+// displayLines shows the menu as a numbered list and reads the choice, by
+// number or ID, from a line of input. A blank line or the end of input goes back.
+func (m *Menu) displayLines(multi bool) (string, bool) {
+	m.layoutColumns(0)
+	for {
+		fmt.Fprintf(m.Terminal, "%s:\n", m.Prompt)
+		for i, item := range m.MenuItems {
+			if multi {
+				checkbox := "[ ]"
+				if m.selected[item.ID] {
+					checkbox = "[x]"
+				}
+				fmt.Fprintf(m.Terminal, "%3d) %s %s\n", i+1, checkbox, item.Text)
+			} else {
+				fmt.Fprintf(m.Terminal, "%3d) %s\n", i+1, item.Text)
+			}
+		}
+		if multi {
+			fmt.Fprintf(m.Terminal, "%s\n", m.selectionStatus())
+			fmt.Fprint(m.Terminal, "Enter numbers separated by commas, or '.' to keep the selection: ")
+		} else {
+			fmt.Fprint(m.Terminal, "Enter a number: ")
+		}
+
+		line, err := lineInput.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if err != nil {
+				fmt.Fprintln(m.Terminal)
+			}
+			return "", false
+		}
+
+		var chosen []string
+		if multi && line == "." {
+			chosen = m.Selected()
+		} else {
+			for _, v := range strings.Split(line, ",") {
+				item := m.lineItem(strings.TrimSpace(v))
+				if item == nil {
+					m.notice = fmt.Sprintf("Invalid choice %q", line)
+					chosen = nil
+					break
+				}
+				chosen = append(chosen, item.ID)
+			}
+			if len(chosen) > 1 && !multi {
+				m.notice = fmt.Sprintf("Choose one of 1 to %d", len(m.MenuItems))
+				chosen = nil
+			}
+		}
+
+		if chosen != nil && (!multi || m.setSelection(chosen) && m.confirmSelection()) {
+			if len(chosen) == 0 {
+				return "", true
+			}
+			return chosen[0], true
+		}
+
+		fmt.Fprintln(m.Terminal, m.notice)
+		m.notice = ""
+		if err != nil {
+			return "", false
+		}
+	}
+}
+
+// lineItem returns the item chosen by a 1-based number or its ID.
+func (m *Menu) lineItem(v string) *MenuItem {
+	if n, err := strconv.Atoi(v); err == nil {
+		if n < 1 || n > len(m.MenuItems) {
+			return nil
+		}
+		return m.MenuItems[n-1]
+	}
+	return m.findItem(v)
+}
@@ -0,0 +1,134 @@
+package menu
+
+// Render draws the menu in a region of a Layout.
+func (m *Menu) Render(width, height int, focused bool) []string {
+	// Leave room for the scroll markers and the filter line
+	reserved := 2
+	if m.filtering {
+		reserved++
+	}
+	if m.sorting {
+		reserved++
+	}
+	if len(m.Columns) > 0 {
+		reserved++
+		m.layoutColumns(width)
+	}
+	m.rows = max(height-reserved, 1)
+	m.applyFilter()
+	m.tick(nil)
+
+	lines := m.itemLines(false)
+	for i, line := range lines {
+		lines[i] = Truncate(line, width)
+	}
+	return lines
+}
+
+// HandleKey moves the cursor and edits the filter of a menu in a Layout. The
+// keys bound to select, back and interrupt are left to the layout.
+func (m *Menu) HandleKey(key Key) bool {
+	_, changed := m.handleKey(key, false)
+	return changed
+}
+
+// Editing reports whether key presses are editing the menu's filter, or
+// choosing the column to sort by.
+func (m *Menu) Editing() bool {
+	return m.filtering || m.sorting
+}
+
+// Current returns the ID of the item under the cursor, or "" when no item
+// matches the filter.
+func (m *Menu) Current() string {
+	if len(m.MenuItems) == 0 {
+		return ""
+	}
+	if m.visible != nil && !m.cursorVisible() {
+		return ""
+	}
+	return m.MenuItems[min(m.CursorPos, len(m.MenuItems)-1)].ID
+}
+
+// SetItems replaces the menu's items, keeping the cursor on the item with the
+// same ID when it is still there, and flashing items whose state changed.
+func (m *Menu) SetItems(items []*MenuItem) {
+	m.flashChanged(items)
+	current := m.Current()
+	m.MenuItems = items
+	m.CursorPos = 0
+	m.sortItems()
+	for i, item := range items {
+		if item.ID == current {
+			m.CursorPos = i
+			break
+		}
+	}
+	m.layoutColumns(m.width)
+	m.applyFilter()
+}
+
+// TextPane is a scrollable pane of text, such as details or logs, for a Layout.
+type TextPane struct {
+	Lines  []string
+	Keymap Keymap
+	// Follow keeps the last line in view as lines are added, unless the user
+	// has scrolled up, like tail -f
+	Follow bool
+
+	offset int
+	height int
+}
+
+func NewTextPane() *TextPane {
+	return &TextPane{Keymap: defaultKeymap}
+}
+
+// SetLines replaces the text, scrolling back to the top unless following.
+func (p *TextPane) SetLines(lines []string) {
+	following := p.Follow && p.offset >= p.bottom()
+	p.Lines = lines
+	if following {
+		p.offset = p.bottom()
+	} else if !p.Follow {
+		p.offset = 0
+	}
+}
+
+// bottom returns the offset showing the last line at the bottom of the pane.
+func (p *TextPane) bottom() int {
+	return max(len(p.Lines)-p.height, 0)
+}
+
+func (p *TextPane) Render(width, height int, focused bool) []string {
+	p.height = height
+	p.offset = min(max(p.offset, 0), p.bottom())
+
+	end := min(p.offset+height, len(p.Lines))
+	lines := make([]string, 0, end-p.offset)
+	for _, line := range p.Lines[p.offset:end] {
+		lines = append(lines, Truncate(line, width))
+	}
+	return lines
+}
+
+func (p *TextPane) HandleKey(key Key) bool {
+	switch p.Keymap[key.Name] {
+	case ACTION_UP:
+		p.offset--
+	case ACTION_DOWN:
+		p.offset++
+	case ACTION_PAGE_UP:
+		p.offset -= p.height
+	case ACTION_PAGE_DOWN:
+		p.offset += p.height
+	case ACTION_HOME:
+		p.offset = 0
+	case ACTION_END:
+		p.offset = p.bottom()
+	default:
+		return false
+	}
+	p.offset = min(max(p.offset, 0), p.bottom())
+	return true
+}
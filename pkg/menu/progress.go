@@ -0,0 +1,165 @@
+package menu
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
+	xterm "golang.org/x/term"
+)
+
+const progressBarWidth = 30
+
+// progressRedrawInterval limits how often byte level updates redraw the bars
+const progressRedrawInterval = 100 * time.Millisecond
+
+type progressSlot struct {
+	label string
+	size  int64
+	done  int64
+}
+
+// Progress renders an overall progress bar plus one bar per concurrent task,
+// redrawing them in place. When out isn't a terminal, such as in CI logs, it
+// prints a plain line per finished task instead. It is safe for concurrent
+// use.
+type Progress struct {
+	mu         sync.Mutex
+	out        io.Writer
+	plain      bool // whether out can't be redrawn, so only finished tasks are printed
+	title      string
+	total      int64
+	done       int64
+	slots      []progressSlot
+	rendered   bool
+	lastRender time.Time
+}
+
+func NewProgress(out io.Writer, title string, total int64, slots int) *Progress {
+	f, ok := out.(*os.File)
+	return &Progress{
+		out:   out,
+		plain: !ok || !xterm.IsTerminal(int(f.Fd())),
+		title: title,
+		total: total,
+		slots: make([]progressSlot, slots),
+	}
+}
+
+// Start shows a new task of size bytes in the given slot.
+func (p *Progress) Start(slot int, label string, size int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.slots[slot] = progressSlot{label: label, size: size}
+	p.render(true)
+}
+
+// Advance records n more bytes completed by the task in slot.
+func (p *Progress) Advance(slot int, n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.slots[slot].done += n
+	p.done += n
+	p.render(false)
+}
+
+// Finish clears the task in slot.
+func (p *Progress) Finish(slot int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.plain {
+		s := p.slots[slot]
+		fmt.Fprintf(p.out, "%s %s (%s/%s)\n", s.label, formatBytes(s.size), formatBytes(p.done), formatBytes(p.total))
+	}
+	p.slots[slot] = progressSlot{}
+	p.render(true)
+}
+
+// Close draws the final state of the overall bar and removes the task bars.
+func (p *Progress) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.slots = nil
+	if p.plain {
+		fmt.Fprintf(p.out, "%s %s/%s\n", p.title, formatBytes(p.done), formatBytes(p.total))
+		return
+	}
+	p.render(true)
+	fmt.Fprintln(p.out)
+}
+
+// Writer returns a writer that advances slot by the number of bytes written,
+// for use with io.TeeReader.
+func (p *Progress) Writer(slot int) io.Writer {
+	return progressWriter{p: p, slot: slot}
+}
+
+type progressWriter struct {
+	p    *Progress
+	slot int
+}
+
+func (w progressWriter) Write(b []byte) (int, error) {
+	w.p.Advance(w.slot, int64(len(b)))
+	return len(b), nil
+}
+
+func (p *Progress) render(force bool) {
+	if p.plain {
+		return
+	}
+	if !force && time.Since(p.lastRender) < progressRedrawInterval {
+		return
+	}
+	p.lastRender = time.Now()
+
+	if p.rendered {
+		// Clear the previously drawn lines and move back up to redraw them
+		fmt.Fprintf(p.out, "\r\033[J")
+	}
+
+	lines := []string{fmt.Sprintf("%s %s %s", theme.Paint(theme.STYLE_TITLE, p.title), progressBar(p.done, p.total), formatBytes(p.done)+"/"+formatBytes(p.total))}
+	for _, s := range p.slots {
+		if s.label == "" {
+			lines = append(lines, "")
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s", progressBar(s.done, s.size), s.label))
+	}
+
+	fmt.Fprint(p.out, strings.Join(lines, "\n"))
+	if len(lines) > 1 {
+		// Leave the cursor at the start of the first line, ready for the next redraw
+		fmt.Fprintf(p.out, "\033[%dA", len(lines)-1)
+	}
+	fmt.Fprint(p.out, "\r")
+	p.rendered = true
+}
+
+func progressBar(done, total int64) string {
+	filled := progressBarWidth
+	if total > 0 {
+		filled = int(done * progressBarWidth / total)
+	}
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+	return "[" + strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled) + "]"
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
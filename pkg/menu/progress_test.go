@@ -0,0 +1,27 @@
+package menu_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/menu"
+)
+
+func TestProgressPlain(t *testing.T) {
+	var b bytes.Buffer
+	p := menu.NewProgress(&b, "Uploading 2 files", 3000, 2)
+	p.Start(0, "a.js", 1000)
+	p.Start(1, "b.css", 2000)
+	io.WriteString(p.Writer(0), strings.Repeat("a", 1000))
+	p.Finish(0)
+	io.WriteString(p.Writer(1), strings.Repeat("b", 2000))
+	p.Finish(1)
+	p.Close()
+
+	want := "a.js 1000B (1000B/2.9KB)\nb.css 2.0KB (2.9KB/2.9KB)\nUploading 2 files 2.9KB/2.9KB\n"
+	if b.String() != want {
+		t.Errorf("output = %q, want %q", b.String(), want)
+	}
+}
@@ -0,0 +1,101 @@
+package menu
+
+import (
+	"fmt"
+	"time"
+)
+
+// flashDuration is how long an item whose state changed stays highlighted
+const flashDuration = 3 * time.Second
+
+// refreshTick is how often a refreshing menu checks for new items and stops
+// flashing items
+const refreshTick = 250 * time.Millisecond
+
+type refreshResult struct {
+	items []*MenuItem
+	err   error
+}
+
+// poll calls Refresh every RefreshInterval, unless paused, until stop is closed.
+func (m *Menu) poll(stop <-chan struct{}) <-chan refreshResult {
+	results := make(chan refreshResult)
+	go func() {
+		ticker := time.NewTicker(m.RefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			if m.paused.Load() {
+				continue
+			}
+
+			items, err := m.Refresh()
+			select {
+			case results <- refreshResult{items: items, err: err}:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return results
+}
+
+// tick applies refreshed items when there are some and stops flashing items
+// whose time is up. It reports whether the menu needs redrawing.
+func (m *Menu) tick(updates <-chan refreshResult) bool {
+	redraw := false
+	select {
+	case r := <-updates:
+		m.refreshErr = r.err
+		if r.err == nil {
+			m.updated = time.Now()
+			m.SetItems(r.items)
+		}
+		redraw = true
+	default:
+	}
+
+	now := time.Now()
+	for id, until := range m.flashes {
+		if now.After(until) {
+			delete(m.flashes, id)
+			redraw = true
+		}
+	}
+	return redraw
+}
+
+// flashChanged flashes the items of items whose state differs from the item
+// with the same ID in the menu.
+func (m *Menu) flashChanged(items []*MenuItem) {
+	states := map[string]string{}
+	for _, item := range m.MenuItems {
+		states[item.ID] = item.State
+	}
+	for _, item := range items {
+		if state, ok := states[item.ID]; ok && state != item.State {
+			if m.flashes == nil {
+				m.flashes = map[string]time.Time{}
+			}
+			m.flashes[item.ID] = time.Now().Add(flashDuration)
+		}
+	}
+}
+
+// refreshStatus describes when the items were last refreshed, shown below a
+// refreshing menu.
+func (m *Menu) refreshStatus() string {
+	key := m.Keymap.keyFor(ACTION_PAUSE)
+	switch {
+	case m.paused.Load():
+		return fmt.Sprintf("   Paused, last updated %s (%s to resume)", m.updated.Format(time.TimeOnly), key)
+	case m.refreshErr != nil:
+		return fmt.Sprintf("   Refresh failed: %s (%s to pause)", m.refreshErr, key)
+	default:
+		return fmt.Sprintf("   Last updated %s, every %s (%s to pause)", m.updated.Format(time.TimeOnly), m.RefreshInterval, key)
+	}
+}
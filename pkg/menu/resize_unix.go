@@ -0,0 +1,17 @@
+//go:build !windows
+
+package menu
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyResize returns a channel signalled when the terminal is resized and
+// a function to stop the notifications.
+func notifyResize() (<-chan os.Signal, func()) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGWINCH)
+	return c, func() { signal.Stop(c) }
+}
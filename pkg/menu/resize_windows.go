@@ -0,0 +1,10 @@
+//go:build windows
+
+package menu
+
+import "os"
+
+// notifyResize is a no-op on Windows, which has no SIGWINCH.
+func notifyResize() (<-chan os.Signal, func()) {
+	return nil, func() {}
+}
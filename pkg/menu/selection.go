@@ -0,0 +1,103 @@
+package menu
+
+import "fmt"
+
+// Select marks the items with the given IDs as selected in a multi-choice menu.
+func (m *Menu) Select(ids ...string) *Menu {
+	if m.selected == nil {
+		m.selected = map[string]bool{}
+	}
+	for _, id := range ids {
+		m.selected[id] = true
+	}
+	return m
+}
+
+// Selected returns the IDs of the selected items, in menu order.
+func (m *Menu) Selected() []string {
+	ids := []string{}
+	for _, item := range m.MenuItems {
+		if m.selected[item.ID] {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
+
+// setSelection replaces the selection if it satisfies the constraints,
+// otherwise it leaves it unchanged and sets a notice explaining why.
+func (m *Menu) setSelection(ids []string) bool {
+	if m.MaxSelected > 0 && len(ids) > m.MaxSelected {
+		m.notice = fmt.Sprintf("Choose at most %d", m.MaxSelected)
+		return false
+	}
+	m.selected = map[string]bool{}
+	m.Select(ids...)
+	return true
+}
+
+// toggle flips whether the item under the cursor is selected.
+func (m *Menu) toggle() {
+	if !m.cursorVisible() {
+		return
+	}
+	id := m.MenuItems[m.CursorPos].ID
+	ids := []string{}
+	for _, s := range m.Selected() {
+		if s != id {
+			ids = append(ids, s)
+		}
+	}
+	if !m.selected[id] {
+		ids = append(ids, id)
+	}
+	m.setSelection(ids)
+}
+
+// selectVisible changes the selection of the items matching the filter, with
+// f returning whether an item ends up selected given whether it was.
+func (m *Menu) selectVisible(f func(selected bool) bool) {
+	visible := map[string]bool{}
+	for _, index := range m.visible {
+		visible[m.MenuItems[index].ID] = true
+	}
+
+	ids := []string{}
+	for _, item := range m.MenuItems {
+		selected := m.selected[item.ID]
+		if visible[item.ID] {
+			selected = f(selected)
+		}
+		if selected {
+			ids = append(ids, item.ID)
+		}
+	}
+	m.setSelection(ids)
+}
+
+// confirmSelection reports whether the selection can be confirmed, setting a
+// notice when too few items are selected.
+func (m *Menu) confirmSelection() bool {
+	if len(m.Selected()) < m.MinSelected {
+		m.notice = fmt.Sprintf("Choose at least %d", m.MinSelected)
+		return false
+	}
+	return true
+}
+
+// selectionStatus describes the selection and its constraints, shown below a
+// multi-choice menu.
+func (m *Menu) selectionStatus() string {
+	status := fmt.Sprintf("%d of %d selected", len(m.Selected()), len(m.MenuItems))
+	switch {
+	case m.MinSelected > 0 && m.MinSelected == m.MaxSelected:
+		status += fmt.Sprintf(", choose %d", m.MinSelected)
+	case m.MinSelected > 0 && m.MaxSelected > 0:
+		status += fmt.Sprintf(", choose %d to %d", m.MinSelected, m.MaxSelected)
+	case m.MinSelected > 0:
+		status += fmt.Sprintf(", choose at least %d", m.MinSelected)
+	case m.MaxSelected > 0:
+		status += fmt.Sprintf(", choose at most %d", m.MaxSelected)
+	}
+	return status
+}
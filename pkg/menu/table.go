@@ -0,0 +1,166 @@
+package menu
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
+)
+
+// columnGap separates the columns of a table
+const columnGap = "  "
+
+// cursorWidth is the width of the cursor drawn before each item
+const cursorWidth = 3
+
+// minColumnWidth is the narrowest a column is squeezed to when the table is
+// wider than the terminal
+const minColumnWidth = 4
+
+// Column is a named column of a menu drawn as a table.
+type Column struct {
+	Name string
+	// Compare orders items by the column, defaulting to comparing the text
+	// of their cells
+	Compare func(a, b *MenuItem) int
+}
+
+// AddRow adds an item drawn as a row of the menu's Columns.
+func (m *Menu) AddRow(id string, cells ...string) *Menu {
+	m.MenuItems = append(m.MenuItems, &MenuItem{ID: id, Cells: cells})
+	return m
+}
+
+// SortBy orders the rows by a column, toggling between ascending and
+// descending when it is already sorted by that column.
+func (m *Menu) SortBy(column int) {
+	if column < 0 || column >= len(m.Columns) {
+		return
+	}
+	if m.sortColumn == column+1 {
+		m.sortDesc = !m.sortDesc
+	} else {
+		m.sortColumn, m.sortDesc = column+1, false
+	}
+	m.sortItems()
+	m.applyFilter()
+}
+
+// handleSortKey sorts by the column whose number key is, after the sort key
+// was pressed. Any other key cancels sorting. It reports whether the menu
+// needs redrawing.
+func (m *Menu) handleSortKey(key Key) bool {
+	if key.Name == KEY_TICK {
+		return false
+	}
+	m.sorting = false
+	if key.Rune >= '1' && key.Rune <= '9' {
+		m.SortBy(int(key.Rune - '1'))
+	}
+	return true
+}
+
+// sortItems orders the items by the sort column, keeping the cursor on the
+// same item.
+func (m *Menu) sortItems() {
+	if m.sortColumn == 0 {
+		return
+	}
+	current := m.Current()
+	column := m.sortColumn - 1
+
+	compare := m.Columns[column].Compare
+	if compare == nil {
+		compare = func(a, b *MenuItem) int {
+			return strings.Compare(strings.ToLower(cell(a, column)), strings.ToLower(cell(b, column)))
+		}
+	}
+	slices.SortStableFunc(m.MenuItems, func(a, b *MenuItem) int {
+		if m.sortDesc {
+			return compare(b, a)
+		}
+		return compare(a, b)
+	})
+
+	for i, item := range m.MenuItems {
+		if item.ID == current {
+			m.CursorPos = i
+		}
+	}
+}
+
+// layoutColumns sizes the columns to fit width and sets the text of each row
+// from its cells, so rows are filtered and drawn like other items.
+func (m *Menu) layoutColumns(width int) {
+	if len(m.Columns) == 0 {
+		return
+	}
+	m.width = width
+
+	widths := make([]int, len(m.Columns))
+	for i, c := range m.Columns {
+		widths[i] = VisibleWidth(c.Name) + 2 // room for the sort indicator
+		for _, item := range m.MenuItems {
+			widths[i] = max(widths[i], VisibleWidth(cell(item, i)))
+		}
+	}
+
+	// Squeeze the widest column until the table fits, or can't shrink further
+	if width > 0 {
+		available := width - cursorWidth - len(columnGap)*(len(widths)-1)
+		for sum(widths) > available {
+			widest := 0
+			for i := range widths {
+				if widths[i] > widths[widest] {
+					widest = i
+				}
+			}
+			if widths[widest] <= minColumnWidth {
+				break
+			}
+			widths[widest]--
+		}
+	}
+	m.columnWidths = widths
+
+	for _, item := range m.MenuItems {
+		cells := make([]string, len(widths))
+		for i, w := range widths {
+			cells[i] = Pad(cell(item, i), w)
+		}
+		item.Text = strings.TrimRight(strings.Join(cells, columnGap), " ")
+	}
+}
+
+// tableHeader returns the header row of the table, marking the sort column.
+func (m *Menu) tableHeader() string {
+	names := make([]string, len(m.columnWidths))
+	for i, w := range m.columnWidths {
+		name := m.Columns[i].Name
+		if m.sortColumn == i+1 {
+			if m.sortDesc {
+				name += " " + theme.G().Down
+			} else {
+				name += " " + theme.G().Up
+			}
+		}
+		names[i] = Pad(name, w)
+	}
+	header := strings.Repeat(" ", cursorWidth) + strings.TrimRight(strings.Join(names, columnGap), " ")
+	return theme.Paint(theme.STYLE_HEADER, header)
+}
+
+func cell(item *MenuItem, column int) string {
+	if column < len(item.Cells) {
+		return item.Cells[column]
+	}
+	return ""
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,101 @@
+package menu
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/buger/goterm"
+	"github.com/pkg/term"
+)
+
+// Terminal is the I/O a menu draws to and reads key presses from.
+type Terminal interface {
+	io.Writer
+	// Read reads raw input. In raw mode it returns after a short poll interval
+	// with no bytes, and a nil or io.EOF error, when no key was pressed.
+	Read(p []byte) (int, error)
+	// Size returns the width and height of the terminal in characters.
+	Size() (width, height int, err error)
+	// MakeRaw switches to raw mode, returning a function to restore the previous mode.
+	MakeRaw() (restore func() error, err error)
+	// Resized is signalled when the terminal changes size while in raw mode.
+	Resized() <-chan os.Signal
+}
+
+var defaultTerminal Terminal = NewTTY()
+
+// SetDefaultTerminal sets the terminal used by menus created afterwards.
+func SetDefaultTerminal(t Terminal) {
+	defaultTerminal = t
+}
+
+// TTY is the real terminal, drawing to Out and reading from /dev/tty so that
+// input still comes from the user when stdin is redirected.
+type TTY struct {
+	Out        io.Writer
+	t          *term.Term
+	resized    <-chan os.Signal
+	stopResize func()
+}
+
+func NewTTY() *TTY {
+	return &TTY{Out: os.Stdout}
+}
+
+func (t *TTY) Write(p []byte) (int, error) {
+	return t.Out.Write(p)
+}
+
+func (t *TTY) Read(p []byte) (int, error) {
+	if t.t == nil {
+		return 0, errors.New("menu: terminal is not in raw mode")
+	}
+	return t.t.Read(p)
+}
+
+func (t *TTY) Size() (int, int, error) {
+	width, height := goterm.Width(), goterm.Height()
+	if width <= 0 || height <= 0 {
+		return 0, 0, errors.New("menu: unable to get the terminal size")
+	}
+	return width, height, nil
+}
+
+func (t *TTY) MakeRaw() (func() error, error) {
+	tty, err := term.Open("/dev/tty")
+	if err != nil {
+		return nil, err
+	}
+
+	err = term.RawMode(tty)
+	if err != nil {
+		tty.Close()
+		return nil, err
+	}
+
+	// Poll for input so a resize can be handled while waiting for a key
+	err = tty.SetReadTimeout(inputPollInterval)
+	if err != nil {
+		tty.Restore()
+		tty.Close()
+		return nil, err
+	}
+
+	t.t = tty
+	t.resized, t.stopResize = notifyResize()
+
+	return func() error {
+		t.stopResize()
+		t.t, t.resized = nil, nil
+		if err := tty.Restore(); err != nil {
+			tty.Close()
+			return err
+		}
+		return tty.Close()
+	}, nil
+}
+
+func (t *TTY) Resized() <-chan os.Signal {
+	return t.resized
+}
@@ -0,0 +1,79 @@
+package menu
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
+)
+
+// resetStyle ends any colour left open by text cut short
+const resetStyle = "\033[0m"
+
+// escapeLen returns the length of the escape sequence at the start of s, or 0.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != escape || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// VisibleWidth returns how many columns s takes up on screen, ignoring
+// colour escape sequences.
+func VisibleWidth(s string) int {
+	width := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		_, n := utf8.DecodeRuneInString(s[i:])
+		i += n
+		width++
+	}
+	return width
+}
+
+// Truncate cuts s to width columns, ending it with an ellipsis when it
+// doesn't fit. Colour escape sequences are kept but not counted.
+func Truncate(s string, width int) string {
+	if VisibleWidth(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	cols := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			b.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		if cols == width-1 {
+			break
+		}
+		_, n := utf8.DecodeRuneInString(s[i:])
+		b.WriteString(s[i : i+n])
+		i += n
+		cols++
+	}
+	b.WriteString(theme.G().Ellipsis)
+	if strings.Contains(s, "\033[") {
+		b.WriteString(resetStyle)
+	}
+	return b.String()
+}
+
+// Pad fits s to exactly width columns, truncating or filling with spaces.
+func Pad(s string, width int) string {
+	s = Truncate(s, width)
+	return s + strings.Repeat(" ", max(width-VisibleWidth(s), 0))
+}
@@ -1,14 +1,64 @@
 package menu
 
+import (
+	"sync/atomic"
+	"time"
+)
+
 type Menu struct {
 	Prompt    string
 	CursorPos int
 	MenuItems []*MenuItem
+	Keymap    Keymap
+	Terminal  Terminal
+	Flag      string // the command line flag that answers this menu without prompting
+
+	// MinSelected and MaxSelected constrain how many items a multi-choice
+	// menu can confirm, a MaxSelected of 0 allows any number
+	MinSelected int
+	MaxSelected int
+
+	// Refresh, when set, is called every RefreshInterval in the background
+	// while the menu is shown, replacing the items with those it returns
+	Refresh         func() ([]*MenuItem, error)
+	RefreshInterval time.Duration
+
+	// Columns, when set, draw the items as the rows of a table of their
+	// Cells, which the sort key followed by a column's number sorts by
+	Columns []Column
+
+	// Actions are extra single key actions on the item under the cursor,
+	// listed in the key hints footer and the help overlay
+	Actions []MenuAction
+
+	filter        string               // the text typed to filter the items
+	filtering     bool                 // whether key presses are editing the filter
+	visible       []int                // indexes of the items matching the filter
+	matches       map[int][]int        // matched rune positions, by item index
+	renderedLines int                  // lines drawn by the last render, for redrawing
+	offset        int                  // first visible item drawn in the viewport
+	rows          int                  // item rows available when drawn in a pane, 0 to fit the terminal
+	selected      map[string]bool      // IDs of the items checked in a multi-choice menu
+	notice        string               // message shown below the menu until the next key
+	helping       bool                 // whether the help overlay is shown instead of the items
+	paused        atomic.Bool          // whether refreshing is paused, read by the polling goroutine
+	updated       time.Time            // when the items were last refreshed
+	refreshErr    error                // the error of the last refresh, if it failed
+	flashes       map[string]time.Time // when items whose state changed stop flashing, by ID
+	sorting       bool                 // whether the next key chooses the column to sort by
+	sortColumn    int                  // the column, counted from 1, the rows are sorted by, 0 for none
+	sortDesc      bool                 // whether the rows are sorted in descending order
+	width         int                  // the width the columns were last sized for
+	columnWidths  []int                // the width of each column
+	err           error                // why input couldn't be read, when the menu went back because of it
 }
 
 type MenuItem struct {
 	Text    string
 	ID      string
+	State   string   // items are flashed when a refresh changes their state
+	Cells   []string // the item's row when the menu has Columns
+	Value   any      // data the item was made from, for comparing rows
 	SubMenu *Menu
 }
 
@@ -16,3 +66,9 @@ type InfoTableData struct {
 	Label string
 	Data  string
 }
+
+// InfoSection is a titled group of rows in a sectioned info table.
+type InfoSection struct {
+	Title string
+	Rows  []InfoTableData
+}
@@ -0,0 +1,84 @@
+package menu
+
+import (
+	"fmt"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
+)
+
+// minViewportRows is the fewest items shown, however small the terminal
+const minViewportRows = 3
+
+// viewportReservedLines are the lines used around the items: the prompt, the
+// filter line, the selection or refresh status, the two scroll markers, the
+// key hints and a spare line for the terminal cursor
+const viewportReservedLines = 7
+
+// viewportRows returns how many items fit in the terminal at once. It returns
+// 0 when the terminal height is unknown, meaning every item is shown.
+func (m *Menu) viewportRows() int {
+	if m.rows > 0 {
+		return m.rows
+	}
+	_, height, err := m.Terminal.Size()
+	if err != nil {
+		return 0
+	}
+	reserved := viewportReservedLines
+	if len(m.Columns) > 0 {
+		// the table header
+		reserved++
+	}
+	return max(height-reserved, minViewportRows)
+}
+
+// cursorRow returns the row of the cursor within the visible items.
+func (m *Menu) cursorRow() int {
+	for r, i := range m.visible {
+		if i == m.CursorPos {
+			return r
+		}
+	}
+	return 0
+}
+
+// moveCursorTo moves the cursor to row of the visible items, without wrapping.
+func (m *Menu) moveCursorTo(row int) {
+	if len(m.visible) == 0 {
+		return
+	}
+	row = min(max(row, 0), len(m.visible)-1)
+	m.CursorPos = m.visible[row]
+}
+
+// pageSize returns how many rows the paging actions move the cursor by.
+func (m *Menu) pageSize() int {
+	if page := m.viewportRows(); page > 0 {
+		return page
+	}
+	return len(m.visible)
+}
+
+// viewport returns the range of visible items to draw, scrolling so the
+// cursor stays in view.
+func (m *Menu) viewport() (int, int) {
+	rows := m.viewportRows()
+	if rows == 0 || len(m.visible) <= rows {
+		m.offset = 0
+		return 0, len(m.visible)
+	}
+
+	row := m.cursorRow()
+	if row < m.offset {
+		m.offset = row
+	} else if row >= m.offset+rows {
+		m.offset = row - rows + 1
+	}
+	m.offset = min(max(m.offset, 0), len(m.visible)-rows)
+
+	return m.offset, m.offset + rows
+}
+
+func scrollMarker(arrow string, n int) string {
+	return theme.Paint(theme.STYLE_SCROLL, fmt.Sprintf("   %s %d more", arrow, n))
+}
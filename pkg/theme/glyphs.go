@@ -0,0 +1,95 @@
+package theme
+
+// Glyphs are the symbols drawn around text, such as the menu cursor and the
+// borders of a full-screen layout.
+type Glyphs struct {
+	Cursor    string // before the item under the cursor, 2 columns wide
+	Checked   string
+	Unchecked string
+	Up        string // scroll markers and sort indicators
+	Down      string
+	Ellipsis  string // ends truncated text
+	Arrow     string // between an old and a new value
+
+	// Deployment states
+	Ready   string
+	Error   string
+	Pending string
+
+	// Box drawing
+	Horizontal  string
+	Vertical    string
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+
+	// File trees
+	Branch     string
+	LastBranch string
+	Indent     string
+}
+
+var UNICODE_GLYPHS = Glyphs{
+	Cursor:    "> ",
+	Checked:   "☒",
+	Unchecked: "☐",
+	Up:        "↑",
+	Down:      "↓",
+	Ellipsis:  "…",
+	Arrow:     "→",
+
+	Ready:   "⏺",
+	Error:   "○",
+	Pending: "⏶",
+
+	Horizontal:  "─",
+	Vertical:    "│",
+	TopLeft:     "┌",
+	TopRight:    "┐",
+	BottomLeft:  "└",
+	BottomRight: "┘",
+
+	Branch:     "├── ",
+	LastBranch: "└── ",
+	Indent:     "│   ",
+}
+
+// ASCII_GLYPHS draws with ASCII only, for terminals and fonts without the
+// Unicode symbols.
+var ASCII_GLYPHS = Glyphs{
+	Cursor:    "> ",
+	Checked:   "[x]",
+	Unchecked: "[ ]",
+	Up:        "^",
+	Down:      "v",
+	Ellipsis:  "~",
+	Arrow:     "->",
+
+	Ready:   "*",
+	Error:   "o",
+	Pending: "^",
+
+	Horizontal:  "-",
+	Vertical:    "|",
+	TopLeft:     "+",
+	TopRight:    "+",
+	BottomLeft:  "+",
+	BottomRight: "+",
+
+	Branch:     "|-- ",
+	LastBranch: "`-- ",
+	Indent:     "|   ",
+}
+
+var glyphs = UNICODE_GLYPHS
+
+// SetGlyphs sets the glyphs returned by G.
+func SetGlyphs(g Glyphs) {
+	glyphs = g
+}
+
+// G returns the glyphs to draw with.
+func G() Glyphs {
+	return glyphs
+}
@@ -0,0 +1,310 @@
+// Package theme styles the text the CLI prints, from named styles looked up in
+// the active theme, and provides the glyphs it draws with.
+package theme
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	xterm "golang.org/x/term"
+)
+
+// Style names
+const (
+	STYLE_PROMPT         = "prompt"
+	STYLE_CURSOR         = "cursor"
+	STYLE_MATCH          = "match"  // characters matching a menu's filter
+	STYLE_FILTER         = "filter" // the filter being typed
+	STYLE_SCROLL         = "scroll" // markers for items scrolled out of view
+	STYLE_HEADER         = "header" // table headers
+	STYLE_NOTICE         = "notice" // messages such as "Choose at least 1"
+	STYLE_FLASH          = "flash"  // items whose state just changed
+	STYLE_BORDER         = "border"
+	STYLE_BORDER_FOCUSED = "border-focused"
+	STYLE_STATUS_BAR     = "status-bar"
+	STYLE_TITLE          = "title" // progress titles
+	STYLE_HINTS          = "hints" // the key hints footer
+	STYLE_STATE_READY    = "state-ready"
+	STYLE_STATE_ERROR    = "state-error"
+	STYLE_STATE_BUILDING = "state-building"
+	STYLE_STATE_QUEUED   = "state-queued"
+	STYLE_STATE_CANCELED = "state-canceled"
+)
+
+// Styles lists every style name, in the order they are documented.
+var Styles = []string{
+	STYLE_PROMPT, STYLE_CURSOR, STYLE_MATCH, STYLE_FILTER, STYLE_SCROLL, STYLE_HEADER,
+	STYLE_NOTICE, STYLE_FLASH, STYLE_BORDER, STYLE_BORDER_FOCUSED, STYLE_STATUS_BAR, STYLE_TITLE,
+	STYLE_HINTS, STYLE_STATE_READY, STYLE_STATE_ERROR, STYLE_STATE_BUILDING, STYLE_STATE_QUEUED, STYLE_STATE_CANCELED,
+}
+
+// Style is a space separated list of attributes: bold, dim, italic,
+// underline and reverse, a colour, and a background colour prefixed with
+// "bg-". Colours are black, red, green, yellow, blue, magenta, cyan and white,
+// optionally prefixed with "bright-", or a number from 0 to 255.
+// For example "bold cyan" or "black bg-bright-yellow".
+type Style string
+
+var attributes = map[string]string{
+	"bold":      "1",
+	"dim":       "2",
+	"italic":    "3",
+	"underline": "4",
+	"reverse":   "7",
+}
+
+var colors = map[string]int{
+	"black": 0, "red": 1, "green": 2, "yellow": 3, "blue": 4, "magenta": 5, "cyan": 6, "white": 7,
+}
+
+// sgr returns the SGR parameters of the style, e.g. "1;36" for "bold cyan".
+func (s Style) sgr() (string, error) {
+	var codes []string
+	for _, word := range strings.Fields(strings.ToLower(string(s))) {
+		if code, ok := attributes[word]; ok {
+			codes = append(codes, code)
+			continue
+		}
+
+		name, background := strings.CutPrefix(word, "bg-")
+		if n, err := strconv.Atoi(name); err == nil && n >= 0 && n <= 255 {
+			if background {
+				codes = append(codes, fmt.Sprintf("48;5;%d", n))
+			} else {
+				codes = append(codes, fmt.Sprintf("38;5;%d", n))
+			}
+			continue
+		}
+
+		name, bright := strings.CutPrefix(name, "bright-")
+		c, ok := colors[name]
+		if !ok {
+			return "", fmt.Errorf("unknown style attribute %q", word)
+		}
+		base := 30
+		if background {
+			base = 40
+		}
+		if bright {
+			base += 60
+		}
+		codes = append(codes, strconv.Itoa(base+c))
+	}
+	return strings.Join(codes, ";"), nil
+}
+
+// Theme maps style names to styles.
+type Theme map[string]Style
+
+// Merge returns a copy of t with the styles of overrides applied on top.
+func (t Theme) Merge(overrides Theme) Theme {
+	merged := maps.Clone(t)
+	maps.Copy(merged, overrides)
+	return merged
+}
+
+// Built in themes, by name
+var Themes = map[string]Theme{
+	"dark": {
+		STYLE_PROMPT:         "bold cyan",
+		STYLE_CURSOR:         "yellow",
+		STYLE_MATCH:          "magenta",
+		STYLE_FILTER:         "cyan",
+		STYLE_SCROLL:         "cyan",
+		STYLE_HEADER:         "bold",
+		STYLE_NOTICE:         "red",
+		STYLE_FLASH:          "reverse",
+		STYLE_BORDER:         "",
+		STYLE_BORDER_FOCUSED: "cyan",
+		STYLE_STATUS_BAR:     "reverse",
+		STYLE_TITLE:          "cyan",
+		STYLE_HINTS:          "bright-black",
+		STYLE_STATE_READY:    "green",
+		STYLE_STATE_ERROR:    "red",
+		STYLE_STATE_BUILDING: "blue",
+		STYLE_STATE_QUEUED:   "",
+		STYLE_STATE_CANCELED: "bright-black",
+	},
+	"light": {
+		STYLE_PROMPT:         "bold blue",
+		STYLE_CURSOR:         "bold magenta",
+		STYLE_MATCH:          "underline red",
+		STYLE_FILTER:         "blue",
+		STYLE_SCROLL:         "blue",
+		STYLE_HEADER:         "bold",
+		STYLE_NOTICE:         "red",
+		STYLE_FLASH:          "reverse",
+		STYLE_BORDER:         "",
+		STYLE_BORDER_FOCUSED: "blue",
+		STYLE_STATUS_BAR:     "reverse",
+		STYLE_TITLE:          "blue",
+		STYLE_HINTS:          "dim",
+		STYLE_STATE_READY:    "green",
+		STYLE_STATE_ERROR:    "red",
+		STYLE_STATE_BUILDING: "blue",
+		STYLE_STATE_QUEUED:   "",
+		STYLE_STATE_CANCELED: "dim",
+	},
+	"high-contrast": {
+		STYLE_PROMPT:         "bold bright-white",
+		STYLE_CURSOR:         "bold black bg-bright-yellow",
+		STYLE_MATCH:          "bold underline bright-cyan",
+		STYLE_FILTER:         "bold bright-white",
+		STYLE_SCROLL:         "bold bright-white",
+		STYLE_HEADER:         "bold underline bright-white",
+		STYLE_NOTICE:         "bold bright-white bg-red",
+		STYLE_FLASH:          "bold black bg-bright-cyan",
+		STYLE_BORDER:         "bright-white",
+		STYLE_BORDER_FOCUSED: "bold bright-yellow",
+		STYLE_STATUS_BAR:     "bold black bg-bright-white",
+		STYLE_TITLE:          "bold bright-white",
+		STYLE_HINTS:          "bright-white",
+		STYLE_STATE_READY:    "bold bright-green",
+		STYLE_STATE_ERROR:    "bold bright-red",
+		STYLE_STATE_BUILDING: "bold bright-cyan",
+		STYLE_STATE_QUEUED:   "bold bright-white",
+		STYLE_STATE_CANCELED: "bright-white",
+	},
+}
+
+// DEFAULT_THEME is used until SetTheme is called
+const DEFAULT_THEME = "dark"
+
+var current = Themes[DEFAULT_THEME]
+
+// SetTheme makes t the theme styles are looked up in, after checking every
+// style in it is valid.
+func SetTheme(t Theme) error {
+	for _, name := range slices.Sorted(maps.Keys(t)) {
+		if _, err := t[name].sgr(); err != nil {
+			return fmt.Errorf("style %s: %w", name, err)
+		}
+	}
+	current = t
+	return nil
+}
+
+// ParseTheme parses comma separated style=attributes pairs, such as
+// "cursor=bold magenta,prompt=cyan", over the theme named by an optional
+// base=name pair, defaulting to DEFAULT_THEME.
+func ParseTheme(spec string, themes map[string]Theme) (Theme, error) {
+	base := DEFAULT_THEME
+	overrides := Theme{}
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, style, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid style %q, expected style=attributes", pair)
+		}
+		name, style = strings.TrimSpace(name), strings.TrimSpace(style)
+		if name == "base" {
+			base = style
+			continue
+		}
+		if !slices.Contains(Styles, name) {
+			return nil, fmt.Errorf("unknown style %q, choose from: %s", name, strings.Join(Styles, ", "))
+		}
+		overrides[name] = Style(style)
+	}
+
+	t, ok := themes[base]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %q", base)
+	}
+	return t.Merge(overrides), nil
+}
+
+// ColorMode is when styles are applied.
+type ColorMode string
+
+const (
+	// COLOR_AUTO applies styles when stdout is a terminal and NO_COLOR isn't set
+	COLOR_AUTO   ColorMode = "auto"
+	COLOR_ALWAYS ColorMode = "always"
+	COLOR_NEVER  ColorMode = "never"
+)
+
+// ParseColorMode parses the value of --color.
+func ParseColorMode(s string) (ColorMode, error) {
+	switch mode := ColorMode(s); mode {
+	case COLOR_AUTO, COLOR_ALWAYS, COLOR_NEVER:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid color mode %q, expected auto, always or never", s)
+}
+
+var (
+	colorMode = COLOR_AUTO
+	enabled   = detectColor()
+)
+
+// SetColorMode sets when styles are applied.
+func SetColorMode(mode ColorMode) {
+	colorMode = mode
+	switch mode {
+	case COLOR_ALWAYS:
+		enabled = true
+	case COLOR_NEVER:
+		enabled = false
+	default:
+		enabled = detectColor()
+	}
+}
+
+// RedetectColor checks again whether to colour output when the mode is auto,
+// for when the environment has changed since, such as by loading a .env file
+// setting NO_COLOR.
+func RedetectColor() {
+	if colorMode == COLOR_AUTO {
+		enabled = detectColor()
+	}
+}
+
+// detectColor reports whether to colour output when the mode is auto, see
+// https://no-color.org.
+func detectColor() bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return xterm.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Enabled reports whether styles are applied.
+func Enabled() bool {
+	return enabled
+}
+
+// Paint returns text in the named style of the current theme. Text is
+// returned unchanged when colours are disabled or the style is empty.
+func Paint(style, text string) string {
+	if !enabled || text == "" {
+		return text
+	}
+	codes, _ := current[style].sgr()
+	if codes == "" {
+		return text
+	}
+	return "\033[" + codes + "m" + text + "\033[0m"
+}
+
+// StateStyle returns the style of a deployment state.
+func StateStyle(state string) string {
+	switch state {
+	case "READY":
+		return STYLE_STATE_READY
+	case "ERROR":
+		return STYLE_STATE_ERROR
+	case "BUILDING", "INITIALIZING":
+		return STYLE_STATE_BUILDING
+	case "CANCELED":
+		return STYLE_STATE_CANCELED
+	default:
+		return STYLE_STATE_QUEUED
+	}
+}
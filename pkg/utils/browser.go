@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// OpenBrowser opens url in the user's browser, the first command in $BROWSER
+// when it is set and otherwise the system's opener, without waiting for it.
+func OpenBrowser(url string) error {
+	var name string
+	var args []string
+	if browser := os.Getenv("BROWSER"); browser != "" {
+		// $BROWSER is a colon separated list of commands, which may place the
+		// URL with %s
+		fields := strings.Fields(strings.Split(browser, ":")[0])
+		if len(fields) == 0 {
+			return errors.New("BROWSER is empty")
+		}
+		name = fields[0]
+		placed := false
+		for _, f := range fields[1:] {
+			if strings.Contains(f, "%s") {
+				f, placed = strings.ReplaceAll(f, "%s", url), true
+			}
+			args = append(args, f)
+		}
+		if !placed {
+			args = append(args, url)
+		}
+	} else {
+		switch runtime.GOOS {
+		case "darwin":
+			name, args = "open", []string{url}
+		case "windows":
+			name, args = "rundll32", []string{"url.dll,FileProtocolHandler", url}
+		default:
+			name, args = "xdg-open", []string{url}
+		}
+	}
+
+	// The browser's output would be drawn over menus, so it is discarded
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("opening %s: %w", url, err)
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// CopyToClipboard copies text to the clipboard of the terminal on stdout with
+// an OSC 52 escape sequence, which also works over SSH. Terminals that don't
+// support it ignore the sequence.
+func CopyToClipboard(text string) error {
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("copying needs stdout to be a terminal")
+	}
+	_, err := fmt.Fprintf(os.Stdout, "\033]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+	return err
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// Clock returns the current time.
+type Clock func() time.Time
+
+var clock Clock = time.Now
+
+// SetClock replaces the clock times are measured against, for example with
+// a fixed time to make output predictable. Passing nil restores time.Now.
+func SetClock(c Clock) {
+	if c == nil {
+		c = time.Now
+	}
+	clock = c
+}
+
+// Now returns the current time of the clock.
+func Now() time.Time {
+	return clock()
+}
+
+// Since returns the time elapsed since t by the clock.
+func Since(t time.Time) time.Duration {
+	return clock().Sub(t)
+}
+
+// FromMillis converts a Unix timestamp in milliseconds, as the Vercel API
+// returns them, to a time. It returns the zero time for 0.
+func FromMillis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+func ElapsedTime(sinceUnix int64) string {
+	// Convert the Unix timestamp to a time.Time
+	since := time.Unix(sinceUnix, 0)
+
+	// Calculate the difference in minutes, hours, and days
+	diff := Since(since)
+	seconds := int(diff.Seconds())
+	minutes := int(diff.Minutes())
+	hours := int(diff.Hours())
+	days := int(diff.Hours() / 24)
+
+	// Return a single unit based on the elapsed time
+	if seconds < 60 {
+		return fmt.Sprintf("%ds ago", seconds)
+	} else if minutes < 60 {
+		return fmt.Sprintf("%dm ago", minutes)
+	} else if hours < 24 {
+		return fmt.Sprintf("%dh ago", hours)
+	} else {
+		return fmt.Sprintf("%dd ago", days)
+	}
+}
+
+// FormatDuration formats d compactly in its two largest units, rounded to
+// the second, such as "45s", "3m12s", "1h05m" or "2d04h". Durations under a
+// second are "<1s".
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	d = d.Round(time.Second)
+	if d < time.Second {
+		return "<1s"
+	}
+
+	days := int(d / (24 * time.Hour))
+	hours := int(d / time.Hour % 24)
+	minutes := int(d / time.Minute % 60)
+	seconds := int(d / time.Second % 60)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%02dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%02dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm%02ds", minutes, seconds)
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
@@ -0,0 +1,50 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/utils"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 0, want: "<1s"},
+		{d: 400 * time.Millisecond, want: "<1s"},
+		{d: 600 * time.Millisecond, want: "1s"},
+		{d: 45 * time.Second, want: "45s"},
+		{d: 3*time.Minute + 12*time.Second, want: "3m12s"},
+		{d: time.Hour + 5*time.Minute + 59*time.Second, want: "1h05m"},
+		{d: 52 * time.Hour, want: "2d04h"},
+		{d: -90 * time.Second, want: "1m30s"},
+	}
+	for _, tt := range tests {
+		if got := utils.FormatDuration(tt.d); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestElapsedTime(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	utils.SetClock(func() time.Time { return now })
+	t.Cleanup(func() { utils.SetClock(nil) })
+
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{ago: 30 * time.Second, want: "30s ago"},
+		{ago: 5 * time.Minute, want: "5m ago"},
+		{ago: 3 * time.Hour, want: "3h ago"},
+		{ago: 50 * time.Hour, want: "2d ago"},
+	}
+	for _, tt := range tests {
+		if got := utils.ElapsedTime(now.Add(-tt.ago).Unix()); got != tt.want {
+			t.Errorf("ElapsedTime(%v ago) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
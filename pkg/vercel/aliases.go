@@ -0,0 +1,93 @@
+package vercel
+
+import (
+	"errors"
+	"net/http"
+)
+
+// projectID resolves a configured project name to its Vercel project ID.
+// An empty name resolves to an empty ID, which lists across all projects.
+func (v *VercelAPI) projectID(projectName string) (string, error) {
+	if projectName == "" {
+		return "", nil
+	}
+	projectId, ok := v.ProjectIDs[projectName]
+	if !ok {
+		return "", errors.New("No project ID found for " + projectName)
+	}
+	return projectId, nil
+}
+
+// ListAliases returns every alias, optionally filtered to a single project.
+func (v *VercelAPI) ListAliases(projectName string) ([]AliasData, error) {
+	var aliases []AliasData
+	projectId, err := v.projectID(projectName)
+	if err != nil {
+		return aliases, err
+	}
+
+	err = v.paginate(func(until int64) (Pagination, error) {
+		url, err := AliasesEndpoint(v.Endpoint, ListOpts{
+			TeamID:    v.TeamID,
+			ProjectID: projectId,
+			Limit:     100,
+			Until:     until,
+		})
+		if err != nil {
+			return Pagination{}, err
+		}
+
+		var page AliasesList
+		if err := v.request(http.MethodGet, url, nil, &page); err != nil {
+			return Pagination{}, err
+		}
+		aliases = append(aliases, page.Aliases...)
+		return page.Pagination, nil
+	})
+
+	return aliases, err
+}
+
+// AssignAlias points alias at the given deployment, moving it if it is already in use.
+func (v *VercelAPI) AssignAlias(deploymentId, alias string) (AliasData, error) {
+	var a AliasData
+
+	url, err := AssignAliasEndpoint(v.Endpoint, AliasOpts{
+		TeamID:       v.TeamID,
+		DeploymentID: deploymentId,
+	})
+	if err != nil {
+		return a, err
+	}
+
+	err = v.request(http.MethodPost, url, AssignAliasBody{Alias: alias}, &a)
+	deployments := []string{deploymentId}
+	if a.OldDeploymentID != "" {
+		deployments = append(deployments, a.OldDeploymentID)
+	}
+	v.audit(AuditEntry{Action: AUDIT_ALIAS_SET, Deployments: deployments, Alias: alias}, err)
+	if err != nil {
+		return a, err
+	}
+	if a.Alias == "" {
+		a.Alias = alias
+	}
+	a.DeploymentID = deploymentId
+
+	return a, nil
+}
+
+// RemoveAlias deletes an alias by its hostname or ID.
+func (v *VercelAPI) RemoveAlias(alias string) error {
+	url, err := AliasEndpoint(v.Endpoint, AliasOpts{
+		TeamID: v.TeamID,
+		Alias:  alias,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = v.request(http.MethodDelete, url, nil, nil)
+	v.audit(AuditEntry{Action: AUDIT_ALIAS_REMOVE, Alias: alias}, err)
+	return err
+}
@@ -0,0 +1,47 @@
+package vercel
+
+// Audit actions, naming the calls that change something on Vercel
+const (
+	AUDIT_CANCEL        = "cancel"
+	AUDIT_REDEPLOY      = "redeploy"
+	AUDIT_DEPLOY        = "deploy"
+	AUDIT_ALIAS_SET     = "alias-set"
+	AUDIT_ALIAS_REMOVE  = "alias-rm"
+	AUDIT_DOMAIN_ADD    = "domain-add"
+	AUDIT_DOMAIN_REMOVE = "domain-rm"
+	AUDIT_DNS_ADD       = "dns-add"
+	AUDIT_DNS_REMOVE    = "dns-rm"
+)
+
+// AuditEntry describes a call that changed something on Vercel, passed to
+// VercelAPI.Audit once the call returns.
+type AuditEntry struct {
+	Action  string `json:"action"`
+	Team    string `json:"team,omitempty"`
+	Project string `json:"project,omitempty"`
+	// Deployments are the deployments acted on. A redeployment lists the
+	// source deployment, then the new one, and an alias the deployment it
+	// was pointed at, then the one it was moved from.
+	Deployments []string `json:"deployments,omitempty"`
+	Alias       string   `json:"alias,omitempty"`
+	Domain      string   `json:"domain,omitempty"`
+	Record      string   `json:"record,omitempty"` // the ID of a DNS record
+	Result      string   `json:"result"`           // "ok", or the error returned
+}
+
+// AUDIT_OK is the result of a call that succeeded.
+const AUDIT_OK = "ok"
+
+// audit passes e, with the team and the result of the call, to the Audit
+// hook when one is set.
+func (v *VercelAPI) audit(e AuditEntry, err error) {
+	if v.Audit == nil {
+		return
+	}
+	e.Team = v.TeamID
+	e.Result = AUDIT_OK
+	if err != nil {
+		e.Result = err.Error()
+	}
+	v.Audit(e)
+}
@@ -0,0 +1,96 @@
+package vercel
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Commit is the Git commit a deployment was built from, whichever provider
+// it came from.
+type Commit struct {
+	Provider    string // github, gitlab or bitbucket
+	Repo        string // owner/name, or the project path on GitLab
+	Branch      string
+	SHA         string
+	Message     string
+	Author      string
+	PullRequest string // the pull or merge request number, if any
+}
+
+// Commit returns the commit of the deployment, from its Git source and the
+// metadata the provider's integration added. It is empty for deployments
+// not made from Git.
+func (d DeploymentData) Commit() Commit {
+	m, g := d.Meta, d.GitSource
+	c := Commit{
+		Provider: g.Type,
+		Branch:   first(g.Branch, m.CommitRef, m.GitlabCommitRef),
+		SHA:      first(g.CommitSHA, m.CommitSHA, m.GitlabCommitSHA),
+		Message:  first(m.CommitMessage, m.GitlabCommitMessage),
+		Author:   first(m.CommitAuthorLogin, m.CommitAuthorName, m.GitlabCommitAuthorName),
+	}
+
+	switch {
+	case m.GitlabProjectPath != "":
+		c.Provider = first(c.Provider, "gitlab")
+		c.Repo = m.GitlabProjectPath
+		c.PullRequest = m.GitlabMRID
+	case m.CommitOrg != "" && m.CommitRepo != "":
+		c.Provider = first(c.Provider, "github")
+		c.Repo = m.CommitOrg + "/" + m.CommitRepo
+		c.PullRequest = m.PRID
+	case g.Org != "" && g.Repo != "":
+		c.Repo = g.Org + "/" + g.Repo
+	}
+	if c.PullRequest == "" && g.PRID != 0 {
+		c.PullRequest = strconv.Itoa(g.PRID)
+	}
+	return c
+}
+
+// Subject returns the first line of the commit message.
+func (c Commit) Subject() string {
+	subject, _, _ := strings.Cut(c.Message, "\n")
+	return subject
+}
+
+// URL returns the address of the commit on its provider, or "" when the
+// repository isn't known.
+func (c Commit) URL() string {
+	if c.Repo == "" || c.SHA == "" {
+		return ""
+	}
+	switch c.Provider {
+	case "github":
+		return "https://github.com/" + c.Repo + "/commit/" + c.SHA
+	case "gitlab":
+		return "https://gitlab.com/" + c.Repo + "/-/commit/" + c.SHA
+	case "bitbucket":
+		return "https://bitbucket.org/" + c.Repo + "/commits/" + c.SHA
+	}
+	return ""
+}
+
+// CompareURL returns the address comparing commit a to commit b on their
+// provider, or "" when they aren't from the same GitHub or GitLab repository.
+func CompareURL(a, b Commit) string {
+	if a.Repo == "" || a.Repo != b.Repo || a.Provider != b.Provider || a.SHA == "" || b.SHA == "" {
+		return ""
+	}
+	switch a.Provider {
+	case "github":
+		return "https://github.com/" + a.Repo + "/compare/" + a.SHA + "..." + b.SHA
+	case "gitlab":
+		return "https://gitlab.com/" + a.Repo + "/-/compare/" + a.SHA + "..." + b.SHA
+	}
+	return ""
+}
+
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
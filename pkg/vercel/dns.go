@@ -0,0 +1,122 @@
+package vercel
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ListDNSRecords returns every DNS record of a domain using Vercel's nameservers.
+func (v *VercelAPI) ListDNSRecords(domain string) ([]DNSRecord, error) {
+	var records []DNSRecord
+
+	err := v.paginate(func(until int64) (Pagination, error) {
+		url, err := DNSRecordsEndpoint(v.Endpoint, domain, ListOpts{
+			TeamID: v.TeamID,
+			Limit:  100,
+			Until:  until,
+		})
+		if err != nil {
+			return Pagination{}, err
+		}
+
+		var page DNSRecordsList
+		if err := v.request(http.MethodGet, url, nil, &page); err != nil {
+			return Pagination{}, err
+		}
+		records = append(records, page.Records...)
+		return page.Pagination, nil
+	})
+
+	return records, err
+}
+
+// CreateDNSRecord adds a record to the domain and returns the new record's ID.
+func (v *VercelAPI) CreateDNSRecord(domain string, record DNSRecord) (string, error) {
+	body, err := NewDNSRecordBody(record)
+	if err != nil {
+		return "", err
+	}
+
+	url, err := CreateDNSRecordEndpoint(v.Endpoint, DNSRecordOpts{TeamID: v.TeamID, Domain: domain})
+	if err != nil {
+		return "", err
+	}
+
+	var resp CreateDNSRecordResponse
+	err = v.request(http.MethodPost, url, body, &resp)
+	v.audit(AuditEntry{Action: AUDIT_DNS_ADD, Domain: domain, Record: resp.UID}, err)
+	return resp.UID, err
+}
+
+// RemoveDNSRecord deletes a record from the domain by its ID.
+func (v *VercelAPI) RemoveDNSRecord(domain, recordId string) error {
+	url, err := DNSRecordEndpoint(v.Endpoint, DNSRecordOpts{
+		TeamID:   v.TeamID,
+		Domain:   domain,
+		RecordID: recordId,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = v.request(http.MethodDelete, url, nil, nil)
+	v.audit(AuditEntry{Action: AUDIT_DNS_REMOVE, Domain: domain, Record: recordId}, err)
+	return err
+}
+
+// NewDNSRecordBody builds the request body for creating record. SRV records
+// carry "weight port target" in their value, with the priority held separately.
+func NewDNSRecordBody(record DNSRecord) (CreateDNSRecordBody, error) {
+	body := CreateDNSRecordBody{
+		Name: record.Name,
+		Type: record.Type,
+		TTL:  record.TTL,
+	}
+
+	switch record.Type {
+	case DNS_MX:
+		body.Value = record.Value
+		body.MXPriority = record.MXPriority
+	case DNS_SRV:
+		f := strings.Fields(record.Value)
+		if len(f) != 3 {
+			return body, errors.New("SRV value must be \"<weight> <port> <target>\"")
+		}
+		weight, err := strconv.Atoi(f[0])
+		if err != nil {
+			return body, fmt.Errorf("invalid SRV weight %q", f[0])
+		}
+		port, err := strconv.Atoi(f[1])
+		if err != nil {
+			return body, fmt.Errorf("invalid SRV port %q", f[1])
+		}
+		body.SRV = &DNSSRV{Priority: record.Priority, Weight: weight, Port: port, Target: f[2]}
+	case DNS_A, DNS_AAAA, DNS_CNAME, DNS_TXT, DNS_CAA:
+		body.Value = record.Value
+	default:
+		return body, fmt.Errorf("unsupported DNS record type %q", record.Type)
+	}
+
+	return body, nil
+}
+
+func ToDNSRecordType(t string) (DNSRecordType, error) {
+	switch DNSRecordType(strings.ToUpper(t)) {
+	case DNS_A, DNS_AAAA, DNS_CNAME, DNS_TXT, DNS_MX, DNS_SRV, DNS_CAA:
+		return DNSRecordType(strings.ToUpper(t)), nil
+	default:
+		return "", errors.New("invalid DNS record type")
+	}
+}
+
+// SameDNSRecord reports whether a and b describe the same record, ignoring IDs and TTLs.
+func SameDNSRecord(a, b DNSRecord) bool {
+	return a.Name == b.Name &&
+		a.Type == b.Type &&
+		a.Value == b.Value &&
+		a.MXPriority == b.MXPriority &&
+		a.Priority == b.Priority
+}
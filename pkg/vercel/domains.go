@@ -0,0 +1,117 @@
+package vercel
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Vercel's recommended records for pointing an externally hosted domain at a project.
+const (
+	RecommendedARecord     = "76.76.21.21"
+	RecommendedCNAMERecord = "cname.vercel-dns.com"
+)
+
+// ListDomains returns every domain registered with the account or team.
+func (v *VercelAPI) ListDomains() ([]DomainData, error) {
+	var domains []DomainData
+
+	err := v.paginate(func(until int64) (Pagination, error) {
+		url, err := DomainsEndpoint(v.Endpoint, ListOpts{
+			TeamID: v.TeamID,
+			Limit:  100,
+			Until:  until,
+		})
+		if err != nil {
+			return Pagination{}, err
+		}
+
+		var page DomainsList
+		if err := v.request(http.MethodGet, url, nil, &page); err != nil {
+			return Pagination{}, err
+		}
+		domains = append(domains, page.Domains...)
+		return page.Pagination, nil
+	})
+
+	return domains, err
+}
+
+// GetDomain returns a single domain, including its verification status.
+func (v *VercelAPI) GetDomain(domain string) (DomainData, error) {
+	var resp DomainResponse
+
+	url, err := DomainEndpoint(v.Endpoint, DomainOpts{TeamID: v.TeamID, Domain: domain})
+	if err != nil {
+		return resp.Domain, err
+	}
+
+	err = v.request(http.MethodGet, url, nil, &resp)
+	return resp.Domain, err
+}
+
+// GetDomainConfig returns how the domain's DNS is currently configured.
+func (v *VercelAPI) GetDomainConfig(domain string) (DomainConfig, error) {
+	var config DomainConfig
+
+	url, err := DomainConfigEndpoint(v.Endpoint, DomainOpts{TeamID: v.TeamID, Domain: domain})
+	if err != nil {
+		return config, err
+	}
+
+	err = v.request(http.MethodGet, url, nil, &config)
+	return config, err
+}
+
+// AddDomain adds an existing, externally registered domain to the account or team.
+func (v *VercelAPI) AddDomain(domain string) (DomainData, error) {
+	var resp DomainResponse
+
+	url, err := DomainsEndpoint(v.Endpoint, ListOpts{TeamID: v.TeamID})
+	if err != nil {
+		return resp.Domain, err
+	}
+
+	err = v.request(http.MethodPost, url, AddDomainBody{Name: domain, Method: "add"}, &resp)
+	v.audit(AuditEntry{Action: AUDIT_DOMAIN_ADD, Domain: domain}, err)
+	return resp.Domain, err
+}
+
+// RemoveDomain removes a domain, along with its aliases and DNS records, from the account or team.
+func (v *VercelAPI) RemoveDomain(domain string) error {
+	url, err := RemoveDomainEndpoint(v.Endpoint, DomainOpts{TeamID: v.TeamID, Domain: domain})
+	if err != nil {
+		return err
+	}
+
+	err = v.request(http.MethodDelete, url, nil, nil)
+	v.audit(AuditEntry{Action: AUDIT_DOMAIN_REMOVE, Domain: domain}, err)
+	return err
+}
+
+// DNSConfigHints returns the records needed to point d at Vercel when its
+// DNS is hosted elsewhere. Apex domains need an A record, subdomains a CNAME.
+// The apex is the one the API reports, so suffixes such as co.uk don't make
+// an apex domain look like a subdomain; without one the domain is its own
+// apex, as the domains of an account are.
+func DNSConfigHints(d DomainData) []string {
+	name := strings.TrimSuffix(d.Name, ".")
+	apex := strings.TrimSuffix(d.ApexName, ".")
+	if apex != "" && !strings.EqualFold(apex, name) {
+		return []string{"CNAME " + d.Name + " " + RecommendedCNAMERecord}
+	}
+	return []string{"A " + d.Name + " " + RecommendedARecord}
+}
+
+// IsVercelNameservers reports whether the domain's nameservers are Vercel's,
+// meaning its DNS records can be managed through the API.
+func IsVercelNameservers(d DomainData) bool {
+	if len(d.Nameservers) == 0 {
+		return false
+	}
+	for _, ns := range d.Nameservers {
+		if !strings.HasSuffix(strings.TrimSuffix(ns, "."), "vercel-dns.com") {
+			return false
+		}
+	}
+	return true
+}
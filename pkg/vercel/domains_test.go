@@ -0,0 +1,29 @@
+package vercel_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+func TestDNSConfigHints(t *testing.T) {
+	tests := []struct {
+		name string
+		d    vercel.DomainData
+		want string
+	}{
+		{name: "apex", d: vercel.DomainData{Name: "example.com", ApexName: "example.com"}, want: "A example.com 76.76.21.21"},
+		{name: "apex under a public suffix", d: vercel.DomainData{Name: "example.co.uk", ApexName: "example.co.uk"}, want: "A example.co.uk 76.76.21.21"},
+		{name: "apex without apexName", d: vercel.DomainData{Name: "example.co.uk"}, want: "A example.co.uk 76.76.21.21"},
+		{name: "subdomain", d: vercel.DomainData{Name: "www.example.com", ApexName: "example.com"}, want: "CNAME www.example.com cname.vercel-dns.com"},
+		{name: "subdomain of a public suffix", d: vercel.DomainData{Name: "shop.example.co.uk", ApexName: "example.co.uk"}, want: "CNAME shop.example.co.uk cname.vercel-dns.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := vercel.DNSConfigHints(tt.d); !slices.Equal(got, []string{tt.want}) {
+				t.Errorf("DNSConfigHints() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -35,6 +35,19 @@ func DeploymentsEndpoint(endpoint string, options DeploymentListOpts) (string, e
 	q.Add("state", states)
 
 	u.RawQuery = q.Encode()
+	withTeam(u, options.TeamID)
+	return u.String(), nil
+}
+
+// ProjectDeploymentsEndpoint lists deployments without a time window, newest
+// first.
+func ProjectDeploymentsEndpoint(endpoint string, options ListOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = "/v6/deployments"
+	withListOpts(u, options)
 	return u.String(), nil
 }
 
@@ -44,6 +57,7 @@ func DeploymentEndpoint(endpoint string, options DeploymentOpts) (string, error)
 		return "", err
 	}
 	u.Path = fmt.Sprintf("/v13/deployments/%s", options.ID)
+	withTeam(u, options.TeamID)
 	return u.String(), nil
 }
 
@@ -53,6 +67,7 @@ func CancelDeploymentEndpoint(endpoint string, options DeploymentOpts) (string,
 		return "", err
 	}
 	u.Path = fmt.Sprintf("/v12/deployments/%s/cancel", options.ID)
+	withTeam(u, options.TeamID)
 	return u.String(), nil
 }
 
@@ -63,10 +78,197 @@ func CreateDeploymentEndpoint(endpoint string, params RedeploymentParams) (strin
 	}
 	u.Path = "/v13/deployments"
 
-	u.Query().Add("teamId", params.TeamID)
 	if params.ForceNew {
-		u.Query().Add("forceNew", "1")
+		q := u.Query()
+		q.Set("forceNew", "1")
+		u.RawQuery = q.Encode()
+	}
+	withTeam(u, params.TeamID)
+
+	return u.String(), nil
+}
+
+// withTeam scopes a request to the given team, when one is configured.
+func withTeam(u *url.URL, teamID string) {
+	if teamID == "" {
+		return
+	}
+	q := u.Query()
+	q.Set("teamId", teamID)
+	u.RawQuery = q.Encode()
+}
+
+// withListOpts adds the common list query parameters used by paginated endpoints.
+func withListOpts(u *url.URL, options ListOpts) {
+	q := u.Query()
+	if options.Limit > 0 {
+		q.Set("limit", strconv.Itoa(options.Limit))
+	}
+	if options.Until > 0 {
+		q.Set("until", strconv.FormatInt(options.Until, 10))
+	}
+	if options.ProjectID != "" {
+		q.Set("projectId", options.ProjectID)
+	}
+	u.RawQuery = q.Encode()
+	withTeam(u, options.TeamID)
+}
+
+func AliasesEndpoint(endpoint string, options ListOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = "/v4/aliases"
+	withListOpts(u, options)
+	return u.String(), nil
+}
+
+func AssignAliasEndpoint(endpoint string, options AliasOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
 	}
+	u.Path = fmt.Sprintf("/v2/deployments/%s/aliases", options.DeploymentID)
+	withTeam(u, options.TeamID)
+	return u.String(), nil
+}
 
+func AliasEndpoint(endpoint string, options AliasOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = fmt.Sprintf("/v2/aliases/%s", options.Alias)
+	withTeam(u, options.TeamID)
+	return u.String(), nil
+}
+
+func DomainsEndpoint(endpoint string, options ListOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = "/v5/domains"
+	withListOpts(u, options)
+	return u.String(), nil
+}
+
+func DomainEndpoint(endpoint string, options DomainOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = fmt.Sprintf("/v5/domains/%s", options.Domain)
+	withTeam(u, options.TeamID)
+	return u.String(), nil
+}
+
+func RemoveDomainEndpoint(endpoint string, options DomainOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = fmt.Sprintf("/v6/domains/%s", options.Domain)
+	withTeam(u, options.TeamID)
+	return u.String(), nil
+}
+
+func DomainConfigEndpoint(endpoint string, options DomainOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = fmt.Sprintf("/v6/domains/%s/config", options.Domain)
+	withTeam(u, options.TeamID)
+	return u.String(), nil
+}
+
+func DNSRecordsEndpoint(endpoint string, domain string, options ListOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = fmt.Sprintf("/v4/domains/%s/records", domain)
+	withListOpts(u, options)
+	return u.String(), nil
+}
+
+func CreateDNSRecordEndpoint(endpoint string, options DNSRecordOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = fmt.Sprintf("/v2/domains/%s/records", options.Domain)
+	withTeam(u, options.TeamID)
+	return u.String(), nil
+}
+
+func DNSRecordEndpoint(endpoint string, options DNSRecordOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = fmt.Sprintf("/v2/domains/%s/records/%s", options.Domain, options.RecordID)
+	withTeam(u, options.TeamID)
+	return u.String(), nil
+}
+
+func DeploymentFilesEndpoint(endpoint string, options DeploymentFileOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = fmt.Sprintf("/v6/deployments/%s/files", options.DeploymentID)
+	withTeam(u, options.TeamID)
+	return u.String(), nil
+}
+
+func DeploymentFileEndpoint(endpoint string, options DeploymentFileOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = fmt.Sprintf("/v7/deployments/%s/files/%s", options.DeploymentID, options.FileID)
+	withTeam(u, options.TeamID)
+	return u.String(), nil
+}
+
+func UploadFileEndpoint(endpoint string, options UploadFileOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = "/v2/files"
+	withTeam(u, options.TeamID)
+	return u.String(), nil
+}
+
+func NewDeploymentEndpoint(endpoint string, options UploadFileOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = "/v13/deployments"
+	q := u.Query()
+	q.Set("skipAutoDetectionConfirmation", "1")
+	u.RawQuery = q.Encode()
+	withTeam(u, options.TeamID)
+	return u.String(), nil
+}
+
+func DeploymentEventsEndpoint(endpoint string, options DeploymentEventsOpts) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	u.Path = fmt.Sprintf("/v3/deployments/%s/events", options.DeploymentID)
+	q := u.Query()
+	q.Set("direction", "backward")
+	if options.Limit > 0 {
+		q.Set("limit", strconv.Itoa(options.Limit))
+	}
+	u.RawQuery = q.Encode()
+	withTeam(u, options.TeamID)
 	return u.String(), nil
 }
@@ -0,0 +1,49 @@
+package vercel
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned for any non-2xx response from the Vercel API.
+type APIError struct {
+	StatusCode int
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	// Missing lists the SHAs of files not yet uploaded, for "missing_files" errors
+	Missing []string `json:"missing"`
+}
+
+type apiErrorBody struct {
+	Error APIError `json:"error"`
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" && e.Message == "" {
+		return fmt.Sprintf("vercel: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("vercel: %s (%d): %s", e.Code, e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is an APIError for a missing resource.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// MissingFiles returns the SHAs Vercel still needs before it can create a
+// deployment, when err is a "missing_files" error.
+func MissingFiles(err error) ([]string, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == "missing_files" {
+		return apiErr.Missing, true
+	}
+	return nil, false
+}
+
+// IsErrorCode reports whether err is an APIError with the given Vercel error code.
+func IsErrorCode(err error, code string) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
@@ -0,0 +1,36 @@
+package vercel
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+)
+
+// GetDeploymentEvents returns up to limit of the most recent build log events
+// of a deployment, oldest first.
+func (v *VercelAPI) GetDeploymentEvents(deploymentId string, limit int) ([]DeploymentEvent, error) {
+	var events []DeploymentEvent
+
+	url, err := DeploymentEventsEndpoint(v.Endpoint, DeploymentEventsOpts{
+		TeamID:       v.TeamID,
+		DeploymentID: deploymentId,
+		Limit:        limit,
+	})
+	if err != nil {
+		return events, err
+	}
+
+	err = v.request(http.MethodGet, url, nil, &events)
+	slices.SortStableFunc(events, func(a, b DeploymentEvent) int {
+		return cmp.Compare(a.Created, b.Created)
+	})
+	return events, err
+}
+
+// Message returns the text of the event, which older events hold outside the payload.
+func (e DeploymentEvent) Message() string {
+	if e.Payload.Text != "" {
+		return e.Payload.Text
+	}
+	return e.Text
+}
@@ -0,0 +1,148 @@
+package vercel
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+)
+
+// GetDeploymentFiles returns the file tree of a deployment.
+func (v *VercelAPI) GetDeploymentFiles(deploymentId string) ([]FileTree, error) {
+	var files []FileTree
+
+	url, err := DeploymentFilesEndpoint(v.Endpoint, DeploymentFileOpts{
+		TeamID:       v.TeamID,
+		DeploymentID: deploymentId,
+	})
+	if err != nil {
+		return files, err
+	}
+
+	err = v.request(http.MethodGet, url, nil, &files)
+	return files, err
+}
+
+// GetDeploymentFileContent returns the contents of a single deployment file.
+// The v7 endpoint answers with a JSON object holding the contents base64
+// encoded in its data field.
+func (v *VercelAPI) GetDeploymentFileContent(deploymentId, fileId string) ([]byte, error) {
+	url, err := DeploymentFileEndpoint(v.Endpoint, DeploymentFileOpts{
+		TeamID:       v.TeamID,
+		DeploymentID: deploymentId,
+		FileID:       fileId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var content DeploymentFileContent
+	if err := v.request(http.MethodGet, url, nil, &content); err != nil {
+		return nil, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(content.Data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding file %s: %w", fileId, err)
+	}
+	return data, nil
+}
+
+// WalkFiles calls fn for every file and directory in tree, depth first, with
+// its slash separated path from the root of the deployment.
+func WalkFiles(tree []FileTree, fn func(p string, f FileTree)) {
+	walkFiles(tree, "", fn)
+}
+
+func walkFiles(tree []FileTree, parent string, fn func(p string, f FileTree)) {
+	for _, f := range tree {
+		p := path.Join(parent, f.Name)
+		fn(p, f)
+		if f.Type == FILE_DIRECTORY {
+			walkFiles(f.Children, p, fn)
+		}
+	}
+}
+
+// FindFile returns the file or directory at p in tree. The root of the
+// deployment is returned as a directory holding the whole tree.
+func FindFile(tree []FileTree, p string) (FileTree, bool) {
+	p = strings.Trim(path.Clean("/"+p), "/")
+	current := FileTree{Name: "", Type: FILE_DIRECTORY, Children: tree}
+	if p == "" {
+		return current, true
+	}
+
+	for _, segment := range strings.Split(p, "/") {
+		found := false
+		for _, child := range current.Children {
+			if child.Name == segment {
+				current = child
+				found = true
+				break
+			}
+		}
+		if !found {
+			return FileTree{}, false
+		}
+	}
+
+	return current, true
+}
+
+type FileChangeKind string
+
+const (
+	FILE_ADDED   FileChangeKind = "added"
+	FILE_REMOVED FileChangeKind = "removed"
+	FILE_CHANGED FileChangeKind = "changed"
+)
+
+// FileChange is a file that differs between two deployments.
+type FileChange struct {
+	Path string
+	Kind FileChangeKind
+}
+
+// DiffFiles returns the files added, removed or changed going from tree a to
+// tree b, sorted by path. Files are compared by their content hash, and
+// symlinks by their target.
+func DiffFiles(a, b []FileTree) []FileChange {
+	before, after := fileHashes(a), fileHashes(b)
+
+	var changes []FileChange
+	for p, hash := range before {
+		switch h, ok := after[p]; {
+		case !ok:
+			changes = append(changes, FileChange{Path: p, Kind: FILE_REMOVED})
+		case h != hash:
+			changes = append(changes, FileChange{Path: p, Kind: FILE_CHANGED})
+		}
+	}
+	for p := range after {
+		if _, ok := before[p]; !ok {
+			changes = append(changes, FileChange{Path: p, Kind: FILE_ADDED})
+		}
+	}
+
+	slices.SortFunc(changes, func(x, y FileChange) int { return strings.Compare(x.Path, y.Path) })
+	return changes
+}
+
+// fileHashes maps the path of every file in tree to what identifies its
+// contents.
+func fileHashes(tree []FileTree) map[string]string {
+	hashes := map[string]string{}
+	WalkFiles(tree, func(p string, f FileTree) {
+		switch f.Type {
+		case FILE_DIRECTORY:
+		case FILE_SYMLINK:
+			hashes[p] = "-> " + f.Symlink
+		default:
+			hashes[p] = f.UID
+		}
+	})
+	return hashes
+}
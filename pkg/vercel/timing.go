@@ -0,0 +1,60 @@
+package vercel
+
+import (
+	"time"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/utils"
+)
+
+// Timing breaks down how long a deployment waited and built for.
+type Timing struct {
+	Queued time.Duration // from being created until its build started
+	Build  time.Duration // from its build starting until it was ready, failed or was cancelled
+	Total  time.Duration // from being created until it was ready, failed or was cancelled
+	// Running is set while the deployment is still queued or building, when
+	// the durations are measured up to now
+	Running bool
+}
+
+// CreatedTime returns when the deployment was created, whether it was listed
+// or fetched by ID.
+func (d DeploymentData) CreatedTime() time.Time {
+	return utils.FromMillis(int64(max(d.CreatedAt, d.Created)))
+}
+
+// Timing returns how long the deployment was queued and built for, measuring
+// unfinished stages up to now. Durations that aren't known are 0.
+func (d DeploymentData) Timing(now time.Time) Timing {
+	created, started := d.CreatedTime(), utils.FromMillis(int64(d.BuildingAt))
+
+	var t Timing
+	end := utils.FromMillis(int64(d.Ready))
+	for _, at := range []int{d.BuildErrorAt, d.CanceledAt} {
+		if end.IsZero() {
+			end = utils.FromMillis(int64(at))
+		}
+	}
+	if end.IsZero() {
+		switch DeploymentState(d.ReadyState) {
+		case READY, ERROR, CANCELED:
+			// Finished, but when wasn't recorded
+		default:
+			end, t.Running = now, true
+		}
+	}
+
+	switch {
+	case !created.IsZero() && !started.IsZero():
+		t.Queued = started.Sub(created)
+	case !created.IsZero() && t.Running:
+		// Still waiting for a build slot
+		t.Queued = now.Sub(created)
+	}
+	if !started.IsZero() && !end.IsZero() {
+		t.Build = end.Sub(started)
+	}
+	if !created.IsZero() && !end.IsZero() {
+		t.Total = end.Sub(created)
+	}
+	return t
+}
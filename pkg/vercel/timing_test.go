@@ -0,0 +1,63 @@
+package vercel_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+func TestDeploymentTiming(t *testing.T) {
+	created := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	ms := func(d time.Duration) int { return int(created.Add(d).UnixMilli()) }
+	now := created.Add(10 * time.Minute)
+
+	tests := []struct {
+		name string
+		d    vercel.DeploymentData
+		want vercel.Timing
+	}{
+		{
+			name: "ready",
+			d:    vercel.DeploymentData{ReadyState: "READY", CreatedAt: ms(0), BuildingAt: ms(5 * time.Second), Ready: ms(65 * time.Second)},
+			want: vercel.Timing{Queued: 5 * time.Second, Build: time.Minute, Total: 65 * time.Second},
+		},
+		{
+			name: "listed, with created instead of createdAt",
+			d:    vercel.DeploymentData{ReadyState: "READY", Created: ms(0), BuildingAt: ms(time.Second), Ready: ms(3 * time.Second)},
+			want: vercel.Timing{Queued: time.Second, Build: 2 * time.Second, Total: 3 * time.Second},
+		},
+		{
+			name: "failed",
+			d:    vercel.DeploymentData{ReadyState: "ERROR", CreatedAt: ms(0), BuildingAt: ms(2 * time.Second), BuildErrorAt: ms(32 * time.Second)},
+			want: vercel.Timing{Queued: 2 * time.Second, Build: 30 * time.Second, Total: 32 * time.Second},
+		},
+		{
+			name: "cancelled while building",
+			d:    vercel.DeploymentData{ReadyState: "CANCELED", CreatedAt: ms(0), BuildingAt: ms(time.Second), CanceledAt: ms(time.Minute)},
+			want: vercel.Timing{Queued: time.Second, Build: 59 * time.Second, Total: time.Minute},
+		},
+		{
+			name: "still building",
+			d:    vercel.DeploymentData{ReadyState: "BUILDING", CreatedAt: ms(0), BuildingAt: ms(4 * time.Minute)},
+			want: vercel.Timing{Queued: 4 * time.Minute, Build: 6 * time.Minute, Total: 10 * time.Minute, Running: true},
+		},
+		{
+			name: "still queued",
+			d:    vercel.DeploymentData{ReadyState: "QUEUED", CreatedAt: ms(0)},
+			want: vercel.Timing{Queued: 10 * time.Minute, Total: 10 * time.Minute, Running: true},
+		},
+		{
+			name: "finished without times",
+			d:    vercel.DeploymentData{ReadyState: "READY", CreatedAt: ms(0)},
+			want: vercel.Timing{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Timing(now); got != tt.want {
+				t.Errorf("Timing() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,9 @@ type VercelAPI struct {
 	Endpoint   string
 	AuthToken  string
 	ProjectIDs map[string]string
+	// Audit, when set, is called after every call that changes something on
+	// Vercel, whether it succeeded or not
+	Audit func(e AuditEntry)
 }
 
 // deployment states
@@ -30,45 +33,137 @@ var DeploymentStates = []DeploymentState{
 type DeploymentAction string
 
 const (
-	EXIT     DeploymentAction = "EXIT"
-	CANCEL   DeploymentAction = "CANCEL"
-	REDEPLOY DeploymentAction = "REDEPLOY"
+	EXIT           DeploymentAction = "EXIT"
+	CANCEL         DeploymentAction = "CANCEL"
+	REDEPLOY       DeploymentAction = "REDEPLOY"
+	FILES          DeploymentAction = "FILES"
+	OPEN           DeploymentAction = "OPEN"
+	OPEN_INSPECTOR DeploymentAction = "OPEN_INSPECTOR"
+	COPY_URL       DeploymentAction = "COPY_URL"
+	COMPARE        DeploymentAction = "COMPARE"
 )
 
 var DeploymentActionsMap = map[DeploymentAction]string{
-	EXIT:     "Exit",
-	CANCEL:   "Cancel",
-	REDEPLOY: "Redeploy",
+	EXIT:           "Exit",
+	CANCEL:         "Cancel",
+	REDEPLOY:       "Redeploy",
+	FILES:          "Browse files",
+	OPEN:           "Open in browser",
+	OPEN_INSPECTOR: "Open inspector in browser",
+	COPY_URL:       "Copy URL",
+	COMPARE:        "Compare with...",
 }
 
 type DeploymentCreator struct {
+	UID      string `json:"uid"`
 	Username string `json:"username"`
+	Email    string `json:"email"`
 }
 
 type DeploymentGitSource struct {
+	Type      string `json:"type"` // github, gitlab or bitbucket
 	Branch    string `json:"ref"`
 	CommitSHA string `json:"sha"`
+	Org       string `json:"org"`
+	Repo      string `json:"repo"`
+	PRID      int    `json:"prId"`
 }
 
 type DeploymentMeta struct {
-	CommitRef string `json:"githubCommitRef"`
+	CommitRef         string `json:"githubCommitRef"`
+	CommitSHA         string `json:"githubCommitSha"`
+	CommitMessage     string `json:"githubCommitMessage"`
+	CommitAuthorName  string `json:"githubCommitAuthorName"`
+	CommitAuthorLogin string `json:"githubCommitAuthorLogin"`
+	CommitOrg         string `json:"githubCommitOrg"`
+	CommitRepo        string `json:"githubCommitRepo"`
+	PRID              string `json:"githubPrId"`
+
+	GitlabCommitRef        string `json:"gitlabCommitRef"`
+	GitlabCommitSHA        string `json:"gitlabCommitSha"`
+	GitlabCommitMessage    string `json:"gitlabCommitMessage"`
+	GitlabCommitAuthorName string `json:"gitlabCommitAuthorName"`
+	GitlabProjectPath      string `json:"gitlabProjectPath"`
+	GitlabMRID             string `json:"gitlabMergeRequestIid"`
+}
+
+// DeploymentError is why an alias failed to be assigned
+type DeploymentError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type DeploymentProjectSettings struct {
+	Framework       string `json:"framework"`
+	BuildCommand    string `json:"buildCommand"`
+	InstallCommand  string `json:"installCommand"`
+	DevCommand      string `json:"devCommand"`
+	OutputDirectory string `json:"outputDirectory"`
+	RootDirectory   string `json:"rootDirectory"`
+	NodeVersion     string `json:"nodeVersion"`
+}
+
+// DeploymentFunction is the configuration of the functions matching a path pattern
+type DeploymentFunction struct {
+	Runtime     string `json:"runtime"`
+	Memory      int    `json:"memory"`      // in MB
+	MaxDuration int    `json:"maxDuration"` // in seconds
+}
+
+type DeploymentLambda struct {
+	ID         string `json:"id"`
+	Entrypoint string `json:"entrypoint"`
+	ReadyState string `json:"readyState"`
+}
+
+type DeploymentCron struct {
+	Schedule string `json:"schedule"`
+	Path     string `json:"path"`
 }
 
+// DeploymentData models a deployment as returned by /v13/deployments/{id}.
+// Deployments listed by /v6/deployments only have some of the fields, and
+// their ID in UID.
 type DeploymentData struct {
-	ID           string              `json:"id"`
-	UID          string              `json:"uid"`
-	Name         string              `json:"name"`
-	Alias        []string            `json:"alias"`
-	URL          string              `json:"url"`
-	Created      int                 `json:"created"`
-	BuildingAt   int                 `json:"buildingAt"`
-	Source       string              `json:"source"`
-	ReadyState   string              `json:"readyState"`
-	Type         string              `json:"type"`
-	Creator      DeploymentCreator   `json:"creator"`
-	InspectorURL string              `json:"inspectorUrl"`
-	GitSource    DeploymentGitSource `json:"gitSource"`
-	Meta         DeploymentMeta      `json:"meta"`
+	ID               string                        `json:"id"`
+	UID              string                        `json:"uid"`
+	Name             string                        `json:"name"`
+	ProjectID        string                        `json:"projectId"`
+	Alias            []string                      `json:"alias"`
+	AutomaticAliases []string                      `json:"automaticAliases"`
+	AliasError       *DeploymentError              `json:"aliasError"`
+	URL              string                        `json:"url"`
+	Created          int                           `json:"created"`   // in listed deployments
+	CreatedAt        int                           `json:"createdAt"` // in deployments fetched by ID
+	BuildingAt       int                           `json:"buildingAt"`
+	Ready            int                           `json:"ready"`
+	BuildErrorAt     int                           `json:"buildErrorAt"`
+	CanceledAt       int                           `json:"canceledAt"`
+	Source           string                        `json:"source"`
+	ReadyState       string                        `json:"readyState"`
+	ReadySubstate    string                        `json:"readySubstate"`
+	ReadyStateReason string                        `json:"readyStateReason"`
+	Type             string                        `json:"type"`
+	Target           string                        `json:"target"`
+	Plan             string                        `json:"plan"`
+	Public           bool                          `json:"public"`
+	Creator          DeploymentCreator             `json:"creator"`
+	InspectorURL     string                        `json:"inspectorUrl"`
+	GitSource        DeploymentGitSource           `json:"gitSource"`
+	Meta             DeploymentMeta                `json:"meta"`
+	ErrorCode        string                        `json:"errorCode"`
+	ErrorMessage     string                        `json:"errorMessage"`
+	ErrorStep        string                        `json:"errorStep"`
+	ErrorLink        string                        `json:"errorLink"`
+	ChecksState      string                        `json:"checksState"`      // registered, running or completed
+	ChecksConclusion string                        `json:"checksConclusion"` // succeeded, failed, skipped or canceled
+	BuildSkipped     bool                          `json:"buildSkipped"`
+	Regions          []string                      `json:"regions"`
+	CreatedIn        string                        `json:"createdIn"`
+	ProjectSettings  DeploymentProjectSettings     `json:"projectSettings"`
+	Functions        map[string]DeploymentFunction `json:"functions"`
+	Lambdas          []DeploymentLambda            `json:"lambdas"`
+	Crons            []DeploymentCron              `json:"crons"`
 }
 
 type DeploymentsList struct {
@@ -76,7 +171,8 @@ type DeploymentsList struct {
 }
 
 type DeploymentOpts struct {
-	ID string `json:"id"`
+	ID     string `json:"id"`
+	TeamID string `json:"teamId,omitempty"`
 }
 
 type DeploymentListOpts struct {
@@ -84,6 +180,7 @@ type DeploymentListOpts struct {
 	ProjectId  string
 	HoursSince int
 	States     []DeploymentState
+	TeamID     string
 }
 
 type RedeploymentParams struct {
@@ -100,3 +197,227 @@ type RedeploymentBody struct {
 	DeploymentId    string                  `json:"deploymentId"`
 	ProjectSettings RedeployProjectSettings `json:"projectSettings"`
 }
+
+// pagination
+type Pagination struct {
+	Count int    `json:"count"`
+	Next  *int64 `json:"next"`
+	Prev  *int64 `json:"prev"`
+}
+
+type ListOpts struct {
+	TeamID    string
+	ProjectID string
+	Limit     int
+	Until     int64
+}
+
+// aliases
+type AliasData struct {
+	UID          string `json:"uid"`
+	Alias        string `json:"alias"`
+	DeploymentID string `json:"deploymentId"`
+	ProjectID    string `json:"projectId"`
+	CreatedAt    int64  `json:"createdAt"`
+	// OldDeploymentID is the deployment an assigned alias was moved from
+	OldDeploymentID string `json:"oldDeploymentId"`
+}
+
+type AliasesList struct {
+	Aliases    []AliasData `json:"aliases"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+type AliasOpts struct {
+	TeamID       string
+	DeploymentID string
+	Alias        string
+}
+
+type AssignAliasBody struct {
+	Alias string `json:"alias"`
+}
+
+// domains
+type DomainData struct {
+	ID                  string   `json:"id"`
+	Name                string   `json:"name"`
+	ApexName            string   `json:"apexName"` // set for domains that are subdomains of another
+	ServiceType         string   `json:"serviceType"`
+	Verified            bool     `json:"verified"`
+	Nameservers         []string `json:"nameservers"`
+	IntendedNameservers []string `json:"intendedNameservers"`
+	CreatedAt           int64    `json:"createdAt"`
+	ExpiresAt           *int64   `json:"expiresAt"`
+}
+
+type DomainsList struct {
+	Domains    []DomainData `json:"domains"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+type DomainResponse struct {
+	Domain DomainData `json:"domain"`
+}
+
+type DomainConfig struct {
+	ConfiguredBy       string   `json:"configuredBy"`
+	Misconfigured      bool     `json:"misconfigured"`
+	AcceptedChallenges []string `json:"acceptedChallenges"`
+}
+
+type DomainOpts struct {
+	TeamID string
+	Domain string
+}
+
+type AddDomainBody struct {
+	Name   string `json:"name"`
+	Method string `json:"method"`
+}
+
+// dns records
+type DNSRecordType string
+
+const (
+	DNS_A     DNSRecordType = "A"
+	DNS_AAAA  DNSRecordType = "AAAA"
+	DNS_CNAME DNSRecordType = "CNAME"
+	DNS_TXT   DNSRecordType = "TXT"
+	DNS_MX    DNSRecordType = "MX"
+	DNS_SRV   DNSRecordType = "SRV"
+	DNS_CAA   DNSRecordType = "CAA"
+)
+
+var DNSRecordTypes = []DNSRecordType{
+	DNS_A, DNS_AAAA, DNS_CNAME, DNS_TXT, DNS_MX, DNS_SRV, DNS_CAA,
+}
+
+type DNSRecord struct {
+	ID         string        `json:"id"`
+	Name       string        `json:"name"`
+	Type       DNSRecordType `json:"type"`
+	Value      string        `json:"value"`
+	MXPriority int           `json:"mxPriority,omitempty"`
+	Priority   int           `json:"priority,omitempty"`
+	TTL        int           `json:"ttl,omitempty"`
+	CreatedAt  int64         `json:"createdAt,omitempty"`
+}
+
+type DNSRecordsList struct {
+	Records    []DNSRecord `json:"records"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+type DNSRecordOpts struct {
+	TeamID   string
+	Domain   string
+	RecordID string
+}
+
+type DNSSRV struct {
+	Priority int    `json:"priority"`
+	Weight   int    `json:"weight"`
+	Port     int    `json:"port"`
+	Target   string `json:"target"`
+}
+
+type CreateDNSRecordBody struct {
+	Name       string        `json:"name"`
+	Type       DNSRecordType `json:"type"`
+	Value      string        `json:"value,omitempty"`
+	TTL        int           `json:"ttl,omitempty"`
+	MXPriority int           `json:"mxPriority,omitempty"`
+	SRV        *DNSSRV       `json:"srv,omitempty"`
+}
+
+type CreateDNSRecordResponse struct {
+	UID string `json:"uid"`
+}
+
+// deployment files
+type FileType string
+
+const (
+	FILE_DIRECTORY  FileType = "directory"
+	FILE_FILE       FileType = "file"
+	FILE_SYMLINK    FileType = "symlink"
+	FILE_LAMBDA     FileType = "lambda"
+	FILE_MIDDLEWARE FileType = "middleware"
+	FILE_INVALID    FileType = "invalid"
+)
+
+type FileTree struct {
+	Name        string     `json:"name"`
+	Type        FileType   `json:"type"`
+	UID         string     `json:"uid"`
+	ContentType string     `json:"contentType"`
+	Mode        int        `json:"mode"`
+	Symlink     string     `json:"symlink"`
+	Children    []FileTree `json:"children"`
+}
+
+type DeploymentFileOpts struct {
+	TeamID       string
+	DeploymentID string
+	FileID       string
+}
+
+// DeploymentFileContent is the response of the v7 file contents endpoint.
+type DeploymentFileContent struct {
+	Data string `json:"data"` // the contents, base64 encoded
+}
+
+// deployment creation
+type DeploymentTarget string
+
+const (
+	PRODUCTION DeploymentTarget = "production"
+	PREVIEW    DeploymentTarget = "preview"
+)
+
+type DeploymentFile struct {
+	File string `json:"file"`
+	SHA  string `json:"sha"`
+	Size int64  `json:"size"`
+	Mode uint32 `json:"mode,omitempty"`
+}
+
+type ProjectSettings struct {
+	Framework       *string `json:"framework,omitempty"`
+	BuildCommand    *string `json:"buildCommand,omitempty"`
+	InstallCommand  *string `json:"installCommand,omitempty"`
+	OutputDirectory *string `json:"outputDirectory,omitempty"`
+	RootDirectory   *string `json:"rootDirectory,omitempty"`
+}
+
+type CreateDeploymentBody struct {
+	Name            string           `json:"name"`
+	Project         string           `json:"project,omitempty"`
+	Target          DeploymentTarget `json:"target,omitempty"`
+	Files           []DeploymentFile `json:"files"`
+	ProjectSettings *ProjectSettings `json:"projectSettings,omitempty"`
+}
+
+type UploadFileOpts struct {
+	TeamID string
+}
+
+type DeploymentEventsOpts struct {
+	TeamID       string
+	DeploymentID string
+	Limit        int
+}
+
+// DeploymentEvent is one line of a deployment's build log.
+type DeploymentEvent struct {
+	Type    string                 `json:"type"` // e.g. "stdout", "stderr", "command"
+	Created int64                  `json:"created"`
+	Text    string                 `json:"text"`
+	Payload DeploymentEventPayload `json:"payload"`
+}
+
+type DeploymentEventPayload struct {
+	Text string `json:"text"`
+	Date int64  `json:"date"`
+}
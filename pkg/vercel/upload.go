@@ -0,0 +1,48 @@
+package vercel
+
+import (
+	"io"
+	"net/http"
+)
+
+// UploadFile uploads the contents of a single file, identified by its SHA1
+// digest, so it can be referenced when creating a deployment.
+func (v *VercelAPI) UploadFile(sha string, size int64, r io.Reader) error {
+	url, err := UploadFileEndpoint(v.Endpoint, UploadFileOpts{TeamID: v.TeamID})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Add("Content-Type", "application/octet-stream")
+	req.Header.Add("x-vercel-digest", sha)
+
+	_, err = v.do(req)
+	return err
+}
+
+// CreateDeployment creates a deployment from previously uploaded files. When
+// files are missing the returned error can be inspected with MissingFiles.
+func (v *VercelAPI) CreateDeployment(body CreateDeploymentBody) (DeploymentData, error) {
+	var deployment DeploymentData
+
+	url, err := NewDeploymentEndpoint(v.Endpoint, UploadFileOpts{TeamID: v.TeamID})
+	if err != nil {
+		return deployment, err
+	}
+
+	err = v.request(http.MethodPost, url, body, &deployment)
+	if _, missing := MissingFiles(err); !missing {
+		// Nothing was deployed while files are still to be uploaded
+		var deployments []string
+		if deployment.ID != "" {
+			deployments = []string{deployment.ID}
+		}
+		v.audit(AuditEntry{Action: AUDIT_DEPLOY, Project: first(body.Project, body.Name), Deployments: deployments}, err)
+	}
+	return deployment, err
+}
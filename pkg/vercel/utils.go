@@ -3,23 +3,20 @@ package vercel
 import (
 	"errors"
 	"fmt"
+	"strings"
 
-	"github.com/fatih/color"
+	"github.com/m87wheeler/golang-vercel-cli/pkg/theme"
 )
 
 func FormatStateString(state string) string {
+	symbol := theme.G().Pending
 	switch state {
 	case string(READY):
-		return color.GreenString(fmt.Sprintf("\u23FA %s", state))
-	case string(ERROR):
-		return color.RedString(fmt.Sprintf("\u25CB %s", state))
-	case string(BUILDING):
-		return color.BlueString(fmt.Sprintf("\u25CB %s", state))
-	case string(CANCELED):
-		return color.HiBlackString(fmt.Sprintf("\u25CB %s", state))
-	default:
-		return fmt.Sprintf("\u23F6 %s", state)
+		symbol = theme.G().Ready
+	case string(ERROR), string(BUILDING), string(CANCELED):
+		symbol = theme.G().Error
 	}
+	return theme.Paint(theme.StateStyle(state), fmt.Sprintf("%s %s", symbol, state))
 }
 
 func ToDeploymentState(state string) (DeploymentState, error) {
@@ -30,3 +27,9 @@ func ToDeploymentState(state string) (DeploymentState, error) {
 		return "", errors.New("invalid deployment state")
 	}
 }
+
+// IsProductionDomain reports whether alias is a custom domain serving the
+// production traffic of a production deployment.
+func IsProductionDomain(d DeploymentData, alias string) bool {
+	return d.Target == "production" && !strings.HasSuffix(alias, ".vercel.app")
+}
@@ -42,7 +42,6 @@ func (v *VercelAPI) GetDeployments(projectName string, limit, hoursSince int, st
 	for _, s := range states {
 		ds, err := ToDeploymentState(s)
 		if err == nil {
-			fmt.Println(err)
 			st = append(st, ds)
 		}
 	}
@@ -52,115 +51,84 @@ func (v *VercelAPI) GetDeployments(projectName string, limit, hoursSince int, st
 		ProjectId:  projectId,
 		HoursSince: -24,
 		States:     st,
+		TeamID:     v.TeamID,
 		// States:     []DeploymentState{BUILDING, READY, CANCELED, ERROR},
 	})
 	if err != nil {
-		fmt.Println(err)
 		return deployments, err
 	}
 
-	resp, err := http.NewRequest(http.MethodGet, url, nil)
-	resp.Header.Add("Authorization", fmt.Sprintf("Bearer %s", v.AuthToken))
-
-	response, err := http.DefaultClient.Do(resp)
-	if err != nil {
-		fmt.Println(err)
-		return deployments, err
-	}
-
-	defer response.Body.Close()
+	err = v.request(http.MethodGet, url, nil, &deployments)
+	return deployments, err
+}
 
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		fmt.Println(err)
-		return deployments, err
-	}
+// GetProjectDeployments returns the newest deployments of the project with
+// the given ID, however long ago they were made.
+func (v *VercelAPI) GetProjectDeployments(projectId string, limit int) (DeploymentsList, error) {
+	var deployments DeploymentsList
 
-	err = json.Unmarshal(body, &deployments)
+	url, err := ProjectDeploymentsEndpoint(v.Endpoint, ListOpts{
+		TeamID:    v.TeamID,
+		ProjectID: projectId,
+		Limit:     limit,
+	})
 	if err != nil {
-		fmt.Println(err)
 		return deployments, err
 	}
 
-	return deployments, nil
+	err = v.request(http.MethodGet, url, nil, &deployments)
+	return deployments, err
 }
 
 func (v *VercelAPI) GetDeployment(deploymentId string) (DeploymentData, error) {
 	var deployment DeploymentData
 
 	url, err := DeploymentEndpoint(v.Endpoint, DeploymentOpts{
-		ID: deploymentId,
+		ID:     deploymentId,
+		TeamID: v.TeamID,
 	})
 	if err != nil {
-		fmt.Println(err)
-		return deployment, err
-	}
-
-	resp, err := http.NewRequest(http.MethodGet, url, nil)
-	resp.Header.Add("Authorization", fmt.Sprintf("Bearer %s", v.AuthToken))
-
-	response, err := http.DefaultClient.Do(resp)
-	if err != nil {
-		fmt.Println(err)
 		return deployment, err
 	}
 
-	defer response.Body.Close()
-
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		fmt.Println(err)
-		return deployment, err
-	}
-
-	err = json.Unmarshal(body, &deployment)
-	if err != nil {
-		fmt.Println(err)
-		return deployment, err
-	}
+	err = v.request(http.MethodGet, url, nil, &deployment)
+	return deployment, err
+}
 
-	return deployment, nil
+// CancelDeployment cancels the build of d, recording its project in the
+// audit log whether or not the cancel succeeds.
+func (v *VercelAPI) CancelDeployment(d DeploymentData) (DeploymentData, error) {
+	deployment, err := v.cancelDeployment(d.ID)
+	v.audit(AuditEntry{Action: AUDIT_CANCEL, Project: d.Name, Deployments: []string{d.ID}}, err)
+	return deployment, err
 }
 
-func (v *VercelAPI) CancelDeployment(deploymentId string) (DeploymentData, error) {
+func (v *VercelAPI) cancelDeployment(deploymentId string) (DeploymentData, error) {
 	var deployment DeploymentData
 
 	url, err := CancelDeploymentEndpoint(v.Endpoint, DeploymentOpts{
-		ID: deploymentId,
+		ID:     deploymentId,
+		TeamID: v.TeamID,
 	})
 	if err != nil {
-		fmt.Println(err)
-		return deployment, err
-	}
-
-	resp, err := http.NewRequest(http.MethodPatch, url, nil)
-	resp.Header.Add("Authorization", fmt.Sprintf("Bearer %s", v.AuthToken))
-
-	response, err := http.DefaultClient.Do(resp)
-	if err != nil {
-		fmt.Println(err)
 		return deployment, err
 	}
 
-	defer response.Body.Close()
-
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		fmt.Println(err)
-		return deployment, err
-	}
+	err = v.request(http.MethodPatch, url, nil, &deployment)
+	return deployment, err
+}
 
-	err = json.Unmarshal(body, &deployment)
-	if err != nil {
-		fmt.Println(err)
-		return deployment, err
+func (v *VercelAPI) CreateRedeployment(sourceDeployment DeploymentData) (DeploymentData, error) {
+	deployment, err := v.createRedeployment(sourceDeployment)
+	deployments := []string{sourceDeployment.ID}
+	if deployment.ID != "" {
+		deployments = append(deployments, deployment.ID)
 	}
-
-	fmt.Printf("Cancelling %s", deployment.ID)
-	return deployment, nil
+	v.audit(AuditEntry{Action: AUDIT_REDEPLOY, Project: sourceDeployment.Name, Deployments: deployments}, err)
+	return deployment, err
 }
 
-func (v *VercelAPI) CreateRedeployment(sourceDeployment DeploymentData) (DeploymentData, error) {
+func (v *VercelAPI) createRedeployment(sourceDeployment DeploymentData) (DeploymentData, error) {
 	var deployment DeploymentData
 
 	url, err := CreateDeploymentEndpoint(
@@ -171,10 +139,8 @@ func (v *VercelAPI) CreateRedeployment(sourceDeployment DeploymentData) (Deploym
 		},
 	)
 	if err != nil {
-		fmt.Println(err)
 		return deployment, err
 	}
-	fmt.Println(url)
 
 	bodyData := RedeploymentBody{
 		Name:         sourceDeployment.Name,
@@ -183,36 +149,88 @@ func (v *VercelAPI) CreateRedeployment(sourceDeployment DeploymentData) (Deploym
 			CommandForIgnoringBuildStep: "",
 		},
 	}
-	jsonBody, err := json.Marshal(bodyData)
+	err = v.request(http.MethodPost, url, bodyData, &deployment)
+	return deployment, err
+}
+
+// request performs an authenticated request against the Vercel API. A non-nil
+// body is sent as JSON and a non-nil out is decoded from the JSON response.
+// Non-2xx responses are returned as an *APIError.
+func (v *VercelAPI) request(method, url string, body any, out any) error {
+	respBody, err := v.requestRaw(method, url, body)
 	if err != nil {
-		fmt.Println(err)
-		return deployment, err
+		return err
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
 	}
+	return json.Unmarshal(respBody, out)
+}
 
-	resp, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
-	resp.Header.Add("Content-Type", "application/json")
-	resp.Header.Add("Authorization", fmt.Sprintf("Bearer %s", v.AuthToken))
+// requestRaw performs an authenticated request like request, returning the
+// undecoded response body.
+func (v *VercelAPI) requestRaw(method, url string, body any) ([]byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewBuffer(jsonBody)
+	}
 
-	response, err := http.DefaultClient.Do(resp)
+	req, err := http.NewRequest(method, url, reqBody)
 	if err != nil {
-		fmt.Println(err)
-		return deployment, err
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
 	}
 
-	defer response.Body.Close()
+	return v.do(req)
+}
 
-	body, err := io.ReadAll(response.Body)
+// do authenticates and sends req, returning the response body. Non-2xx
+// responses are returned as an *APIError.
+func (v *VercelAPI) do(req *http.Request) ([]byte, error) {
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", v.AuthToken))
+
+	response, err := http.DefaultClient.Do(req)
 	if err != nil {
-		fmt.Println(err)
-		return deployment, err
+		return nil, err
 	}
 
-	err = json.Unmarshal(body, &deployment)
+	defer response.Body.Close()
+
+	respBody, err := io.ReadAll(response.Body)
 	if err != nil {
-		fmt.Println(err)
-		return deployment, err
+		return nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		var e apiErrorBody
+		// the error body is best effort, the status code is always reported
+		_ = json.Unmarshal(respBody, &e)
+		e.Error.StatusCode = response.StatusCode
+		return nil, &e.Error
 	}
 
-	fmt.Printf("Redeploying %s", deployment.ID)
-	return deployment, nil
+	return respBody, nil
+}
+
+// paginate calls fetch for each page of a list endpoint, starting with the most
+// recent page and following the `next` cursor until there are no pages left.
+func (v *VercelAPI) paginate(fetch func(until int64) (Pagination, error)) error {
+	var until int64
+	for {
+		p, err := fetch(until)
+		if err != nil {
+			return err
+		}
+		if p.Next == nil {
+			return nil
+		}
+		until = *p.Next
+	}
 }
@@ -0,0 +1,414 @@
+package vercel
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DefaultDNSRecordTTL is the TTL Vercel applies to records created without one.
+const DefaultDNSRecordTTL = 60
+
+// ZoneFileError describes a line of a zone file that could not be imported.
+type ZoneFileError struct {
+	Line int
+	Msg  string
+}
+
+func (e *ZoneFileError) Error() string {
+	return fmt.Sprintf("zone file line %d: %s", e.Line, e.Msg)
+}
+
+// WriteZoneFile writes records to w in BIND zone file format. Records of types
+// ParseZoneFile doesn't support are written commented out.
+func WriteZoneFile(w io.Writer, domain string, records []DNSRecord) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "; %s\n", domain)
+	fmt.Fprintf(bw, "$ORIGIN %s.\n", strings.TrimSuffix(domain, "."))
+
+	for _, r := range records {
+		name := r.Name
+		if name == "" {
+			name = "@"
+		}
+		ttl := r.TTL
+		if ttl == 0 {
+			ttl = DefaultDNSRecordTTL
+		}
+
+		var rdata string
+		switch r.Type {
+		case DNS_CNAME:
+			rdata = fqdn(r.Value)
+		case DNS_MX:
+			rdata = fmt.Sprintf("%d %s", r.MXPriority, fqdn(r.Value))
+		case DNS_SRV:
+			f := strings.Fields(r.Value)
+			if len(f) == 3 {
+				f[2] = fqdn(f[2])
+			}
+			rdata = fmt.Sprintf("%d %s", r.Priority, strings.Join(f, " "))
+		case DNS_TXT:
+			rdata = quoteTXT(r.Value)
+		default:
+			rdata = r.Value
+		}
+
+		// Types ParseZoneFile can't read, such as ALIAS or HTTPS, are kept
+		// as comments so the file can be imported again
+		if _, err := ToDNSRecordType(string(r.Type)); err != nil {
+			fmt.Fprint(bw, "; unsupported: ")
+		}
+		fmt.Fprintf(bw, "%s\t%d\tIN\t%s\t%s\n", name, ttl, r.Type, rdata)
+	}
+
+	return bw.Flush()
+}
+
+// ParseZoneFile reads the records of domain from a BIND zone file. SOA and
+// apex NS records are skipped, since Vercel manages them itself.
+func ParseZoneFile(r io.Reader, domain string) ([]DNSRecord, error) {
+	domain = strings.TrimSuffix(domain, ".")
+	origin := domain
+	ttl := DefaultDNSRecordTTL
+	lastName := ""
+
+	var records []DNSRecord
+	lines, err := zoneFileLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range lines {
+		fields := l.fields
+		if len(fields) == 0 {
+			continue
+		}
+		lineErr := func(format string, a ...any) error {
+			return &ZoneFileError{Line: l.number, Msg: fmt.Sprintf(format, a...)}
+		}
+
+		// Directives
+		switch strings.ToUpper(fields[0]) {
+		case "$ORIGIN":
+			if len(fields) != 2 {
+				return nil, lineErr("$ORIGIN needs a single domain")
+			}
+			origin = strings.TrimSuffix(fields[1], ".")
+			continue
+		case "$TTL":
+			if len(fields) != 2 {
+				return nil, lineErr("$TTL needs a single value")
+			}
+			ttl, err = strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, lineErr("invalid $TTL %q", fields[1])
+			}
+			continue
+		}
+
+		// An indented record inherits the previous record's name
+		name := lastName
+		if !l.indented {
+			name, err = relativeName(fields[0], origin, domain)
+			if err != nil {
+				return nil, lineErr("%s", err)
+			}
+			fields = fields[1:]
+		}
+		lastName = name
+
+		record := DNSRecord{Name: name, TTL: ttl}
+		for len(fields) > 0 {
+			if n, err := strconv.Atoi(fields[0]); err == nil {
+				record.TTL = n
+			} else if f := strings.ToUpper(fields[0]); f != "IN" && f != "CH" && f != "HS" {
+				break
+			}
+			fields = fields[1:]
+		}
+		if len(fields) == 0 {
+			return nil, lineErr("missing record type")
+		}
+
+		t := strings.ToUpper(fields[0])
+		rdata := fields[1:]
+		if t == "SOA" || (t == "NS" && name == "") {
+			continue
+		}
+		record.Type, err = ToDNSRecordType(t)
+		if err != nil {
+			return nil, lineErr("unsupported record type %s", t)
+		}
+
+		switch record.Type {
+		case DNS_A, DNS_AAAA:
+			if len(rdata) != 1 {
+				return nil, lineErr("%s record needs a single address", t)
+			}
+			record.Value = rdata[0]
+		case DNS_CNAME:
+			if len(rdata) != 1 {
+				return nil, lineErr("CNAME record needs a single target")
+			}
+			record.Value = absoluteName(rdata[0], origin)
+		case DNS_MX:
+			if len(rdata) != 2 {
+				return nil, lineErr("MX record needs a priority and a target")
+			}
+			record.MXPriority, err = strconv.Atoi(rdata[0])
+			if err != nil {
+				return nil, lineErr("invalid MX priority %q", rdata[0])
+			}
+			record.Value = absoluteName(rdata[1], origin)
+		case DNS_SRV:
+			if len(rdata) != 4 {
+				return nil, lineErr("SRV record needs a priority, weight, port and target")
+			}
+			record.Priority, err = strconv.Atoi(rdata[0])
+			if err != nil {
+				return nil, lineErr("invalid SRV priority %q", rdata[0])
+			}
+			record.Value = strings.Join([]string{rdata[1], rdata[2], absoluteName(rdata[3], origin)}, " ")
+		case DNS_TXT:
+			if len(rdata) == 0 {
+				return nil, lineErr("TXT record needs a value")
+			}
+			var value strings.Builder
+			for _, s := range rdata {
+				value.WriteString(unquote(s))
+			}
+			record.Value = value.String()
+		case DNS_CAA:
+			if len(rdata) != 3 {
+				return nil, lineErr("CAA record needs flags, a tag and a value")
+			}
+			record.Value = fmt.Sprintf("%s %s %s", rdata[0], rdata[1], quoteCharacterString(unquote(rdata[2])))
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+type zoneFileLine struct {
+	number   int
+	indented bool
+	fields   []string
+}
+
+// zoneFileLines splits a zone file into logical lines of fields, stripping
+// comments and joining records that span multiple lines with parentheses.
+func zoneFileLines(r io.Reader) ([]zoneFileLine, error) {
+	var lines []zoneFileLine
+	var current *zoneFileLine
+	depth := 0
+
+	s := bufio.NewScanner(r)
+	n := 0
+	for s.Scan() {
+		n++
+		text := s.Text()
+		if current == nil {
+			current = &zoneFileLine{
+				number:   n,
+				indented: len(text) > 0 && (text[0] == ' ' || text[0] == '\t'),
+			}
+		}
+
+		fields, err := zoneFileFields(text)
+		if err != nil {
+			return nil, &ZoneFileError{Line: n, Msg: err.Error()}
+		}
+		for _, f := range fields {
+			switch f {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			default:
+				current.fields = append(current.fields, f)
+			}
+		}
+		if depth < 0 {
+			return nil, &ZoneFileError{Line: n, Msg: "unbalanced parentheses"}
+		}
+
+		if depth == 0 {
+			lines = append(lines, *current)
+			current = nil
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if depth != 0 {
+		return nil, &ZoneFileError{Line: n, Msg: "unbalanced parentheses"}
+	}
+
+	return lines, nil
+}
+
+// zoneFileFields splits a single line into whitespace separated fields,
+// keeping quoted strings intact and dropping anything after a ';' comment.
+func zoneFileFields(line string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	inQuotes := false
+	inField := false
+
+	flush := func() {
+		if inField {
+			fields = append(fields, field.String())
+			field.Reset()
+			inField = false
+		}
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case inQuotes:
+			field.WriteByte(c)
+			if c == '\\' && i+1 < len(line) {
+				i++
+				field.WriteByte(line[i])
+			} else if c == '"' {
+				inQuotes = false
+			}
+		case c == ';':
+			flush()
+			return fields, nil
+		case c == '"':
+			inQuotes = true
+			inField = true
+			field.WriteByte(c)
+		case c == '(' || c == ')':
+			flush()
+			fields = append(fields, string(c))
+		case c == ' ' || c == '\t':
+			flush()
+		default:
+			inField = true
+			field.WriteByte(c)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quoted string")
+	}
+	flush()
+
+	return fields, nil
+}
+
+// relativeName converts a zone file owner name to a record name relative to domain.
+func relativeName(name, origin, domain string) (string, error) {
+	abs := absoluteName(name, origin)
+	if abs == domain {
+		return "", nil
+	}
+	if !strings.HasSuffix(abs, "."+domain) {
+		return "", fmt.Errorf("%s is outside of %s", abs, domain)
+	}
+	return strings.TrimSuffix(abs, "."+domain), nil
+}
+
+// absoluteName qualifies a zone file name with origin, without a trailing dot.
+func absoluteName(name, origin string) string {
+	if name == "@" {
+		return origin
+	}
+	if strings.HasSuffix(name, ".") {
+		return strings.TrimSuffix(name, ".")
+	}
+	return name + "." + origin
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") || strings.Contains(name, " ") {
+		return name
+	}
+	return name + "."
+}
+
+// MAX_CHARACTER_STRING is the longest character-string a record can hold,
+// in bytes. Longer TXT values are split over several.
+const MAX_CHARACTER_STRING = 255
+
+// quoteTXT writes a TXT value as quoted RFC 1035 character-strings, split
+// into as many as it needs.
+func quoteTXT(value string) string {
+	if value == "" {
+		return `""`
+	}
+	var parts []string
+	for len(value) > MAX_CHARACTER_STRING {
+		parts = append(parts, quoteCharacterString(value[:MAX_CHARACTER_STRING]))
+		value = value[MAX_CHARACTER_STRING:]
+	}
+	if value != "" {
+		parts = append(parts, quoteCharacterString(value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteCharacterString quotes s as an RFC 1035 character-string, escaping
+// quotes and backslashes with a backslash and other non-printable bytes as
+// \DDD, their decimal value.
+func quoteCharacterString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c > 0x7e:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// unquote reads an RFC 1035 character-string, quoted or not, where \DDD is
+// the byte with decimal value DDD and a backslash before any other
+// character stands for that character.
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 == len(s) {
+			b.WriteByte(c)
+			continue
+		}
+		if i+3 < len(s) && isDigits(s[i+1:i+4]) {
+			if n, err := strconv.Atoi(s[i+1 : i+4]); err == nil && n <= 255 {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		i++
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
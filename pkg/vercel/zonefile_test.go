@@ -0,0 +1,151 @@
+package vercel_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/m87wheeler/golang-vercel-cli/pkg/vercel"
+)
+
+func TestParseZoneFile(t *testing.T) {
+	long := strings.Repeat("a", 255)
+	tests := []struct {
+		name string
+		zone string
+		want []vercel.DNSRecord
+	}{
+		{
+			name: "apex and subdomain",
+			zone: "@ 300 IN A 76.76.21.21\nwww IN CNAME cname.vercel-dns.com.\n",
+			want: []vercel.DNSRecord{
+				{Name: "", Type: vercel.DNS_A, Value: "76.76.21.21", TTL: 300},
+				{Name: "www", Type: vercel.DNS_CNAME, Value: "cname.vercel-dns.com", TTL: vercel.DefaultDNSRecordTTL},
+			},
+		},
+		{
+			name: "origin and ttl directives",
+			zone: "$TTL 3600\n$ORIGIN api.example.com.\n@ A 1.2.3.4\nv1 A 1.2.3.5\nmail.example.com. MX 10 mx\n",
+			want: []vercel.DNSRecord{
+				{Name: "api", Type: vercel.DNS_A, Value: "1.2.3.4", TTL: 3600},
+				{Name: "v1.api", Type: vercel.DNS_A, Value: "1.2.3.5", TTL: 3600},
+				{Name: "mail", Type: vercel.DNS_MX, Value: "mx.api.example.com", MXPriority: 10, TTL: 3600},
+			},
+		},
+		{
+			name: "inherited owner name",
+			zone: "www A 1.2.3.4\n\tAAAA ::1 ; same name\n",
+			want: []vercel.DNSRecord{
+				{Name: "www", Type: vercel.DNS_A, Value: "1.2.3.4", TTL: vercel.DefaultDNSRecordTTL},
+				{Name: "www", Type: vercel.DNS_AAAA, Value: "::1", TTL: vercel.DefaultDNSRecordTTL},
+			},
+		},
+		{
+			name: "multi-line parentheses",
+			zone: "_sip._tcp SRV (\n  10 ; priority\n  5 5060\n  sip.example.com. )\n",
+			want: []vercel.DNSRecord{
+				{Name: "_sip._tcp", Type: vercel.DNS_SRV, Value: "5 5060 sip.example.com", Priority: 10, TTL: vercel.DefaultDNSRecordTTL},
+			},
+		},
+		{
+			name: "TXT joins character-strings and reads escapes",
+			zone: `@ TXT "` + long + `" "b\"c\\d\009e\195\169"` + "\n",
+			want: []vercel.DNSRecord{
+				{Name: "", Type: vercel.DNS_TXT, Value: long + "b\"c\\d\teé", TTL: vercel.DefaultDNSRecordTTL},
+			},
+		},
+		{
+			name: "CAA value is requoted",
+			zone: "@ CAA 0 issue \"letsencrypt.org\"\n",
+			want: []vercel.DNSRecord{
+				{Name: "", Type: vercel.DNS_CAA, Value: `0 issue "letsencrypt.org"`, TTL: vercel.DefaultDNSRecordTTL},
+			},
+		},
+		{
+			name: "SOA and apex NS are skipped",
+			zone: "@ SOA ns1.vercel-dns.com. hostmaster. ( 1 2 3 4 5 )\n@ NS ns1.vercel-dns.com.\n",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := vercel.ParseZoneFile(strings.NewReader(tt.zone), "example.com")
+			if err != nil {
+				t.Fatalf("ParseZoneFile() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseZoneFile() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		zone string
+		line int
+	}{
+		{name: "unbalanced parentheses", zone: "@ A 1.2.3.4\nwww SRV ( 10 5 5060\n", line: 2},
+		{name: "unterminated quote", zone: "@ TXT \"open\n", line: 1},
+		{name: "unsupported type", zone: "@ A 1.2.3.4\n@ ALIAS example.net.\n", line: 2},
+		{name: "outside the domain", zone: "www.example.org. A 1.2.3.4\n", line: 1},
+		{name: "MX without a priority", zone: "@ MX mail\n", line: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := vercel.ParseZoneFile(strings.NewReader(tt.zone), "example.com")
+			var zerr *vercel.ZoneFileError
+			if !errors.As(err, &zerr) {
+				t.Fatalf("ParseZoneFile() error = %v, want a *ZoneFileError", err)
+			}
+			if zerr.Line != tt.line {
+				t.Errorf("error on line %d, want %d: %v", zerr.Line, tt.line, err)
+			}
+		})
+	}
+}
+
+func TestWriteZoneFileSplitsTXT(t *testing.T) {
+	value := strings.Repeat("x", 254) + "\x01" + "y"
+	var b bytes.Buffer
+	err := vercel.WriteZoneFile(&b, "example.com", []vercel.DNSRecord{{Name: "t", Type: vercel.DNS_TXT, Value: value}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "t\t60\tIN\tTXT\t\"" + strings.Repeat("x", 254) + `\001" "y"` + "\n"
+	if !strings.HasSuffix(b.String(), want) {
+		t.Errorf("WriteZoneFile() =\n%s\nwant it to end with\n%s", b.String(), want)
+	}
+}
+
+func TestZoneFileRoundTrip(t *testing.T) {
+	records := []vercel.DNSRecord{
+		{Name: "", Type: vercel.DNS_A, Value: "76.76.21.21", TTL: 300},
+		{Name: "www", Type: vercel.DNS_CNAME, Value: "cname.vercel-dns.com", TTL: 60},
+		{Name: "", Type: vercel.DNS_MX, Value: "mx.example.net", MXPriority: 10, TTL: 60},
+		{Name: "_sip._tcp", Type: vercel.DNS_SRV, Value: "5 5060 sip.example.com", Priority: 10, TTL: 60},
+		{Name: "", Type: vercel.DNS_TXT, Value: "v=spf1 include:_spf.example.net ~all", TTL: 60},
+		{Name: "long", Type: vercel.DNS_TXT, Value: strings.Repeat("k", 300) + "\"quoted\" \\ \té", TTL: 60},
+		{Name: "empty", Type: vercel.DNS_TXT, Value: "", TTL: 60},
+		{Name: "", Type: vercel.DNS_CAA, Value: `0 issue "letsencrypt.org"`, TTL: 60},
+	}
+	unsupported := []vercel.DNSRecord{
+		{Name: "", Type: "ALIAS", Value: "example.net", TTL: 60},
+		{Name: "sub", Type: "NS", Value: "ns1.example.net", TTL: 60},
+	}
+
+	var b bytes.Buffer
+	if err := vercel.WriteZoneFile(&b, "example.com", append(records, unsupported...)); err != nil {
+		t.Fatal(err)
+	}
+	got, err := vercel.ParseZoneFile(&b, "example.com")
+	if err != nil {
+		t.Fatalf("ParseZoneFile() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, records) {
+		t.Errorf("round trip =\n%+v\nwant\n%+v", got, records)
+	}
+}